@@ -53,6 +53,9 @@ func (s *server) start() error {
 		if stats.Generation%plotEach == 0 {
 			plotSolution(stats.Best)
 			fmt.Printf("[%d]: distance: %v\n", stats.Generation, stats.BestFitness)
+			for _, op := range stats.Operators {
+				fmt.Printf("\t%s: improvement rate %.3f, mean delta %.2f\n", op.Name, op.ImprovementRate(), op.MeanDelta())
+			}
 		}
 	})
 
@@ -49,7 +49,11 @@ func runTSP(cities []point, obs engine.Observer[[]int]) (*evolve.Population[[]in
 	eval := newRouteEvaluator(cities)
 
 	generational := engine.Generational[[]int]{
-		Operator:  operator.Pipeline[[]int]{xover, mut},
+		Operator: &operator.Instrumented[[]int]{
+			Pipeline:  operator.Pipeline[[]int]{xover, mut},
+			Evaluator: eval,
+			Names:     []string{"pmx", "slice-order"},
+		},
 		Evaluator: eval,
 		Selection: &selection.RouletteWheel[[]int]{},
 		Elites:    4,
@@ -70,7 +74,11 @@ func runTSP(cities []point, obs engine.Observer[[]int]) (*evolve.Population[[]in
 
 	eng.EndConditions = append(eng.EndConditions, &userAbort)
 
+	// Plotting blocks until the browser reads the solution, so observers are
+	// notified asynchronously, only keeping the most recent generation.
 	eng.AddObserver(obs)
+	eng.ObserverQueue = 1
+	eng.ObserverPolicy = engine.Coalesce
 
 	pop, cond, err := eng.Evolve(100)
 	fmt.Printf("TSP ended, reason: %v\n", cond)
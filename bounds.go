@@ -0,0 +1,131 @@
+package evolve
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// ErrInvalidBounds is the error returned by NewBounds when the lower and upper
+// bounds don't describe a valid range.
+var ErrInvalidBounds = errors.New("invalid bounds")
+
+// A RepairStrategy defines how Bounds repairs the genes of a real-valued
+// vector that are out of range.
+type RepairStrategy int
+
+const (
+	// RepairClip sets an out-of-range gene to the nearest bound.
+	RepairClip RepairStrategy = iota
+
+	// RepairReflect reflects an out-of-range gene back into the range, as if
+	// bounds were mirrors.
+	RepairReflect
+
+	// RepairWrap wraps an out-of-range gene around the range, as if the range
+	// was periodic.
+	RepairWrap
+
+	// RepairResample replaces an out-of-range gene with a value uniformly
+	// distributed in the range.
+	RepairResample
+)
+
+// Bounds describes the range of each dimension of a real-valued vector: the
+// i-th gene is in the [Min[i], Max[i]] range.
+type Bounds struct {
+	Min, Max []float64
+}
+
+// NewBounds returns the Bounds made of the min and max lower and upper bounds.
+//
+// NewBounds returns ErrInvalidBounds if min and max don't have the same length,
+// are empty, or if a lower bound is greater than the corresponding upper bound.
+func NewBounds(min, max []float64) (Bounds, error) {
+	if len(min) != len(max) || len(min) == 0 {
+		return Bounds{}, ErrInvalidBounds
+	}
+	for i := range min {
+		if !(min[i] <= max[i]) {
+			return Bounds{}, ErrInvalidBounds
+		}
+	}
+	return Bounds{
+		Min: append([]float64(nil), min...),
+		Max: append([]float64(nil), max...),
+	}, nil
+}
+
+// UniformBounds returns the Bounds of a vector of n dimensions, all of them
+// being in the [min, max] range.
+func UniformBounds(n int, min, max float64) Bounds {
+	b := Bounds{Min: make([]float64, n), Max: make([]float64, n)}
+	for i := 0; i < n; i++ {
+		b.Min[i], b.Max[i] = min, max
+	}
+	return b
+}
+
+// Dim returns the number of dimensions.
+func (b Bounds) Dim() int { return len(b.Min) }
+
+// Contains reports whether all genes of x are within bounds.
+func (b Bounds) Contains(x []float64) bool {
+	for i := range x {
+		if !(x[i] >= b.Min[i] && x[i] <= b.Max[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Repair modifies in place the genes of x that are out of range, according to
+// strategy, and returns the number of repaired genes. rng is only used by
+// RepairResample.
+func (b Bounds) Repair(x []float64, strategy RepairStrategy, rng *rand.Rand) int {
+	n := 0
+	for i := range x {
+		min, max := b.Min[i], b.Max[i]
+		if x[i] >= min && x[i] <= max {
+			continue
+		}
+		n++
+
+		w := max - min
+		if w == 0 || math.IsNaN(x[i]) || math.IsInf(x[i], 0) {
+			// There's no meaningful way to reflect nor wrap.
+			if strategy == RepairResample {
+				x[i] = min + rng.Float64()*w
+			} else {
+				x[i] = math.Max(min, math.Min(max, x[i]))
+				if math.IsNaN(x[i]) {
+					x[i] = min
+				}
+			}
+			continue
+		}
+
+		switch strategy {
+		case RepairClip:
+			x[i] = math.Max(min, math.Min(max, x[i]))
+		case RepairReflect:
+			y := math.Mod(x[i]-min, 2*w)
+			if y < 0 {
+				y += 2 * w
+			}
+			if y > w {
+				y = 2*w - y
+			}
+			x[i] = min + y
+		case RepairWrap:
+			y := math.Mod(x[i]-min, w)
+			if y < 0 {
+				y += w
+			}
+			x[i] = min + y
+		case RepairResample:
+			x[i] = min + rng.Float64()*w
+		}
+	}
+	return n
+}
@@ -0,0 +1,83 @@
+package evolve
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBounds(t *testing.T) {
+	_, err := NewBounds([]float64{0, 1}, []float64{1})
+	assert.ErrorIs(t, err, ErrInvalidBounds)
+
+	_, err = NewBounds(nil, nil)
+	assert.ErrorIs(t, err, ErrInvalidBounds)
+
+	_, err = NewBounds([]float64{0, 2}, []float64{1, 1})
+	assert.ErrorIs(t, err, ErrInvalidBounds)
+
+	_, err = NewBounds([]float64{math.NaN()}, []float64{1})
+	assert.ErrorIs(t, err, ErrInvalidBounds)
+
+	min, max := []float64{-1, 0}, []float64{1, 0}
+	b, err := NewBounds(min, max)
+	require.NoError(t, err)
+	assert.Equal(t, 2, b.Dim())
+
+	// Bounds must not share the given slices.
+	min[0] = 10
+	assert.Equal(t, -1.0, b.Min[0])
+}
+
+func TestBoundsRepair(t *testing.T) {
+	b := UniformBounds(1, 0, 10)
+
+	tests := []struct {
+		name     string
+		strategy RepairStrategy
+		x, want  float64
+	}{
+		{"clip/in", RepairClip, 4, 4},
+		{"clip/below", RepairClip, -3, 0},
+		{"clip/above", RepairClip, 12, 10},
+		{"reflect/below", RepairReflect, -3, 3},
+		{"reflect/above", RepairReflect, 12, 8},
+		{"reflect/far above", RepairReflect, 23, 3},
+		{"reflect/far below", RepairReflect, -13, 7},
+		{"wrap/below", RepairWrap, -3, 7},
+		{"wrap/above", RepairWrap, 12, 2},
+		{"wrap/far above", RepairWrap, 33, 3},
+		{"clip/+inf", RepairClip, math.Inf(1), 10},
+		{"reflect/-inf", RepairReflect, math.Inf(-1), 0},
+		{"wrap/nan", RepairWrap, math.NaN(), 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x := []float64{tt.x}
+			n := b.Repair(x, tt.strategy, nil)
+			assert.InDelta(t, tt.want, x[0], 1e-9)
+			if tt.x == tt.want {
+				assert.Zero(t, n)
+			} else {
+				assert.Equal(t, 1, n)
+			}
+		})
+	}
+}
+
+func TestBoundsRepairResample(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	b, err := NewBounds([]float64{0, -5, 3}, []float64{1, 5, 3})
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		x := []float64{0.5, -7, 4}
+		n := b.Repair(x, RepairResample, rng)
+		assert.Equal(t, 2, n)
+		assert.Equal(t, 0.5, x[0], "in-range gene should be left untouched")
+		assert.True(t, b.Contains(x), "repaired vector %v is out of bounds", x)
+	}
+}
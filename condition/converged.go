@@ -0,0 +1,23 @@
+package condition
+
+import (
+	"fmt"
+
+	"github.com/arl/evolve"
+)
+
+// Converged is a condition that is met when the population has lost its
+// diversity, that is when the standard deviation of fitness scores falls to or
+// below a given value.
+type Converged[T any] float64
+
+// IsSatisfied returns true if the standard deviation of fitness scores is lower
+// than or equal to the condition value.
+func (c Converged[T]) IsSatisfied(stats *evolve.PopulationStats[T]) bool {
+	return stats.StdDev <= float64(c)
+}
+
+// String returns a string representation of this condition.
+func (c Converged[T]) String() string {
+	return fmt.Sprintf("Converged (fitness standard deviation <= %v)", float64(c))
+}
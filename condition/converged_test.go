@@ -0,0 +1,20 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/arl/evolve"
+)
+
+func TestConverged(t *testing.T) {
+	cond := Converged[any](0.5)
+	stats := &evolve.PopulationStats[any]{}
+	stats.StdDev = 1.2
+	if cond.IsSatisfied(stats) {
+		t.Errorf("stddev = %v, termination condition should not be satisfied", stats.StdDev)
+	}
+	stats.StdDev = 0.5
+	if !cond.IsSatisfied(stats) {
+		t.Errorf("stddev = %v, termination condition should be satisfied", stats.StdDev)
+	}
+}
@@ -0,0 +1,22 @@
+package condition
+
+import (
+	"fmt"
+
+	"github.com/arl/evolve"
+)
+
+// EvaluationCount is a condition that is met when a number of fitness
+// evaluations have been performed, that is when the evaluation budget has
+// been consumed.
+type EvaluationCount[T any] int
+
+// IsSatisfied reports whether or not evolution should finish at the current point.
+func (n EvaluationCount[T]) IsSatisfied(stats *evolve.PopulationStats[T]) bool {
+	return stats.Evaluations >= int(n)
+}
+
+// String returns a string representation of this condition.
+func (n EvaluationCount[T]) String() string {
+	return fmt.Sprintf("Reached %d evaluations", n)
+}
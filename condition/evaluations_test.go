@@ -0,0 +1,22 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/arl/evolve"
+)
+
+func TestEvaluationCount(t *testing.T) {
+	cond := EvaluationCount[any](1000)
+	stats := &evolve.PopulationStats[any]{}
+
+	stats.Evaluations = 999
+	if cond.IsSatisfied(stats) {
+		t.Errorf("evaluations = %v, termination condition should not be satisfied", stats.Evaluations)
+	}
+
+	stats.Evaluations = 1000
+	if !cond.IsSatisfied(stats) {
+		t.Errorf("evaluations = %v, termination condition should be satisfied", stats.Evaluations)
+	}
+}
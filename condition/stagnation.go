@@ -0,0 +1,51 @@
+package condition
+
+import (
+	"fmt"
+
+	"github.com/arl/evolve"
+)
+
+// Stagnation is a condition that is met when the best fitness of the
+// population hasn't improved for a number of generations.
+//
+// Stagnation zero-value is a valid condition, that is satisfied as soon as the
+// best fitness doesn't improve from one generation to the next.
+type Stagnation[T any] struct {
+	// Generations is the number of successive generations without improvement
+	// after which the condition is satisfied.
+	Generations int
+
+	best    float64
+	count   int
+	started bool
+}
+
+// IsSatisfied reports whether the best fitness hasn't improved for the given
+// number of generations.
+func (s *Stagnation[T]) IsSatisfied(stats *evolve.PopulationStats[T]) bool {
+	improved := !s.started ||
+		stats.Natural && stats.BestFitness > s.best ||
+		!stats.Natural && stats.BestFitness < s.best
+	if improved {
+		s.best = stats.BestFitness
+		s.count = 0
+		s.started = true
+		return false
+	}
+	s.count++
+	return s.count >= s.Generations
+}
+
+// Reset forgets the best fitness seen so far, so that the condition may be
+// reused.
+func (s *Stagnation[T]) Reset() {
+	s.best = 0
+	s.count = 0
+	s.started = false
+}
+
+// String returns a string representation of this condition.
+func (s *Stagnation[T]) String() string {
+	return fmt.Sprintf("Stagnated for %d generations", s.Generations)
+}
@@ -0,0 +1,53 @@
+package condition
+
+import (
+	"testing"
+
+	"github.com/arl/evolve"
+)
+
+func TestStagnation(t *testing.T) {
+	t.Run("natural fitness", func(t *testing.T) {
+		cond := &Stagnation[any]{Generations: 2}
+		stats := &evolve.PopulationStats[any]{Natural: true}
+
+		for i, fitness := range []float64{1, 2, 2} {
+			stats.BestFitness = fitness
+			if cond.IsSatisfied(stats) {
+				t.Fatalf("generation %d: termination condition should not be satisfied", i)
+			}
+		}
+		// Improvement resets the count.
+		stats.BestFitness = 3
+		if cond.IsSatisfied(stats) {
+			t.Fatalf("fitness improved, termination condition should not be satisfied")
+		}
+		stats.BestFitness = 3
+		cond.IsSatisfied(stats)
+		stats.BestFitness = 1
+		if !cond.IsSatisfied(stats) {
+			t.Errorf("no improvement for 2 generations, termination condition should be satisfied")
+		}
+
+		cond.Reset()
+		if cond.IsSatisfied(stats) {
+			t.Errorf("after Reset, termination condition should not be satisfied")
+		}
+	})
+
+	t.Run("non-natural fitness", func(t *testing.T) {
+		cond := &Stagnation[any]{Generations: 1}
+		stats := &evolve.PopulationStats[any]{Natural: false}
+
+		stats.BestFitness = 5
+		cond.IsSatisfied(stats)
+		stats.BestFitness = 4
+		if cond.IsSatisfied(stats) {
+			t.Fatalf("fitness improved, termination condition should not be satisfied")
+		}
+		stats.BestFitness = 6
+		if !cond.IsSatisfied(stats) {
+			t.Errorf("fitness didn't improve, termination condition should be satisfied")
+		}
+	})
+}
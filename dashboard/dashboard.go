@@ -0,0 +1,249 @@
+// Package dashboard provides a live web dashboard for evolution runs.
+//
+// A Dashboard is both an engine observer and an http.Handler. Once registered
+// as an observer of an engine, it streams population statistics to connected
+// browsers, via server-sent events, and serves an embedded page plotting the
+// fitness and diversity curves, and rendering the best candidate.
+//
+// The dashboard also provides controls to pause, resume and abort the
+// evolution. Pausing blocks the engine in the observer callback. Aborting is
+// performed through the condition returned by Condition, which must be added to
+// the engine termination conditions.
+package dashboard
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+)
+
+//go:embed index.html
+var indexHTML []byte
+
+// MaxHistory is the maximum number of generations sent to newly connected
+// clients, so that they can plot the evolution that took place before they
+// connected. Only the last generation carries the rendered best candidate.
+const MaxHistory = 10000
+
+// A Dashboard serves a live web dashboard of an evolution run.
+type Dashboard[T any] struct {
+	// Format renders the best candidate. If nil, the best candidate is not
+	// shown.
+	Format func(T) string
+
+	// SVG indicates whether Format renders candidates as SVG images. If false,
+	// they're shown as preformatted text.
+	SVG bool
+
+	mux *http.ServeMux
+
+	mu      sync.Mutex
+	resumed *sync.Cond
+	paused  bool
+	clients map[chan []byte]struct{}
+	history [][]byte // events without the best candidate
+	latest  []byte   // last event, with the best candidate
+	abort   condition.UserAbort[T]
+}
+
+// New returns a new Dashboard.
+func New[T any]() *Dashboard[T] {
+	d := &Dashboard[T]{
+		mux:     http.NewServeMux(),
+		clients: make(map[chan []byte]struct{}),
+	}
+	d.resumed = sync.NewCond(&d.mu)
+	d.mux.HandleFunc("/", d.serveIndex)
+	d.mux.HandleFunc("/events", d.serveEvents)
+	d.mux.HandleFunc("/pause", d.control(d.Pause))
+	d.mux.HandleFunc("/resume", d.control(d.Resume))
+	d.mux.HandleFunc("/abort", d.control(d.Abort))
+	return d
+}
+
+// An event is sent to clients for each generation.
+type event struct {
+	Generation  int
+	BestFitness number
+	Mean        number
+	StdDev      number
+	Natural     bool
+	Size        int
+	Evaluations int
+	Elapsed     float64 // seconds
+	Best        string  `json:",omitempty"`
+	SVG         bool
+	Paused      bool
+}
+
+// A number is a float64 encoded as null in JSON when it's not finite, since
+// JSON has no representation for NaN and infinities.
+type number float64
+
+func (n number) MarshalJSON() ([]byte, error) {
+	f := float64(n)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return []byte("null"), nil
+	}
+	return strconv.AppendFloat(nil, f, 'g', -1, 64), nil
+}
+
+// Observe broadcasts the population statistics to all connected clients. If
+// the dashboard is paused, Observe blocks until it's resumed or aborted.
+func (d *Dashboard[T]) Observe(stats *evolve.PopulationStats[T]) {
+	ev := event{
+		Generation:  stats.Generation,
+		BestFitness: number(stats.BestFitness),
+		Mean:        number(stats.Mean),
+		StdDev:      number(stats.StdDev),
+		Natural:     stats.Natural,
+		Size:        stats.Size,
+		Evaluations: stats.Evaluations,
+		Elapsed:     stats.Elapsed.Seconds(),
+		SVG:         d.SVG,
+	}
+	if d.Format != nil {
+		ev.Best = d.Format(stats.Best)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	ev.Paused = d.paused
+	if buf, err := json.Marshal(ev); err == nil {
+		// The best candidate, possibly large, is only kept for the last event.
+		ev.Best = ""
+		slim, _ := json.Marshal(ev)
+		d.history = append(d.history, slim)
+		if len(d.history) > MaxHistory {
+			d.history = d.history[len(d.history)-MaxHistory:]
+		}
+		d.latest = buf
+		d.broadcast(buf)
+	}
+
+	for d.paused {
+		d.resumed.Wait()
+	}
+}
+
+// broadcast sends an event to all clients. Clients that are too slow to keep
+// up miss events. d.mu must be held.
+func (d *Dashboard[T]) broadcast(buf []byte) {
+	for c := range d.clients {
+		select {
+		case c <- buf:
+		default:
+		}
+	}
+}
+
+// Condition returns the termination condition that is satisfied once the
+// evolution has been aborted from the dashboard.
+func (d *Dashboard[T]) Condition() evolve.Condition[T] { return &d.abort }
+
+// Pause pauses the evolution: the next call to Observe blocks until Resume or
+// Abort is called.
+func (d *Dashboard[T]) Pause() {
+	d.mu.Lock()
+	d.paused = true
+	d.mu.Unlock()
+}
+
+// Resume resumes a paused evolution.
+func (d *Dashboard[T]) Resume() {
+	d.mu.Lock()
+	d.paused = false
+	d.resumed.Broadcast()
+	d.mu.Unlock()
+}
+
+// Abort aborts the evolution, and resumes it if it was paused, so that the
+// engine can terminate.
+func (d *Dashboard[T]) Abort() {
+	d.abort.Abort()
+	d.Resume()
+}
+
+// Paused reports whether the evolution is paused.
+func (d *Dashboard[T]) Paused() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.paused
+}
+
+// ServeHTTP serves the dashboard page, the event stream and the controls.
+func (d *Dashboard[T]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mux.ServeHTTP(w, r)
+}
+
+func (d *Dashboard[T]) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(indexHTML)
+}
+
+func (d *Dashboard[T]) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	c := make(chan []byte, 64)
+	d.mu.Lock()
+	var history [][]byte
+	if len(d.history) > 0 {
+		history = append(d.history[:len(d.history)-1:len(d.history)-1], d.latest)
+	}
+	d.clients[c] = struct{}{}
+	d.mu.Unlock()
+
+	defer func() {
+		d.mu.Lock()
+		delete(d.clients, c)
+		d.mu.Unlock()
+	}()
+
+	for _, buf := range history {
+		fmt.Fprintf(w, "data: %s\n\n", buf)
+	}
+	flusher.Flush()
+
+	for {
+		select {
+		case buf := <-c:
+			fmt.Fprintf(w, "data: %s\n\n", buf)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+// control returns an handler calling f on POST requests.
+func (d *Dashboard[T]) control(f func()) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		f()
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,228 @@
+package dashboard
+
+import (
+	"bufio"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arl/evolve"
+)
+
+func TestDashboardEvents(t *testing.T) {
+	d := New[string]()
+	d.Format = func(s string) string { return "<" + s + ">" }
+	srv := httptest.NewServer(d)
+	defer srv.Close()
+
+	// This generation is sent to the client as history.
+	d.Observe(&evolve.PopulationStats[string]{Best: "a", BestFitness: 1, Generation: 0})
+
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+
+	events := make(chan event)
+	go func() {
+		s := bufio.NewScanner(resp.Body)
+		for s.Scan() {
+			if !strings.HasPrefix(s.Text(), "data: ") {
+				continue
+			}
+			data := strings.TrimPrefix(s.Text(), "data: ")
+			var ev event
+			if err := json.Unmarshal([]byte(data), &ev); err != nil {
+				t.Error(err)
+				return
+			}
+			events <- ev
+		}
+	}()
+
+	next := func() event {
+		select {
+		case ev := <-events:
+			return ev
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for event")
+		}
+		return event{}
+	}
+
+	if ev := next(); ev.Generation != 0 || ev.Best != "<a>" {
+		t.Errorf("got event %+v, want generation 0 and best <a>", ev)
+	}
+
+	// Wait for the client to be registered before sending a live event.
+	for {
+		d.mu.Lock()
+		n := len(d.clients)
+		d.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	d.Observe(&evolve.PopulationStats[string]{Best: "b", BestFitness: 2, Generation: 1})
+	if ev := next(); ev.Generation != 1 || ev.BestFitness != 2 || ev.Best != "<b>" {
+		t.Errorf("got event %+v, want generation 1, fitness 2 and best <b>", ev)
+	}
+}
+
+func TestDashboardPauseResume(t *testing.T) {
+	d := New[int]()
+	srv := httptest.NewServer(d)
+	defer srv.Close()
+
+	post := func(path string) {
+		t.Helper()
+		resp, err := http.Post(srv.URL+path, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("POST %s: status = %d, want %d", path, resp.StatusCode, http.StatusNoContent)
+		}
+	}
+
+	post("/pause")
+	if !d.Paused() {
+		t.Fatal("dashboard should be paused")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.Observe(&evolve.PopulationStats[int]{})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Observe should block while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	post("/resume")
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Observe should return after resume")
+	}
+}
+
+func TestDashboardAbort(t *testing.T) {
+	d := New[int]()
+	d.Pause()
+
+	done := make(chan struct{})
+	go func() {
+		d.Observe(&evolve.PopulationStats[int]{})
+		close(done)
+	}()
+
+	cond := d.Condition()
+	if cond.IsSatisfied(nil) {
+		t.Fatal("condition should not be satisfied before abort")
+	}
+
+	d.Abort()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Observe should return after abort")
+	}
+	if !cond.IsSatisfied(nil) {
+		t.Error("condition should be satisfied after abort")
+	}
+}
+
+func TestDashboardControlMethod(t *testing.T) {
+	d := New[int]()
+	for _, path := range []string{"/pause", "/resume", "/abort"} {
+		rec := httptest.NewRecorder()
+		d.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusMethodNotAllowed {
+			t.Errorf("GET %s: status = %d, want %d", path, rec.Code, http.StatusMethodNotAllowed)
+		}
+	}
+	if d.Paused() || d.Condition().IsSatisfied(nil) {
+		t.Error("GET requests should not affect the evolution")
+	}
+}
+
+func TestDashboardIndex(t *testing.T) {
+	d := New[int]()
+	rec := httptest.NewRecorder()
+	d.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if !strings.Contains(rec.Body.String(), "EventSource") {
+		t.Error("index page should subscribe to the event stream")
+	}
+	if !strings.Contains(rec.Body.String(), "source.onopen") {
+		t.Error("index page should reset the history replayed on reconnection")
+	}
+}
+
+func TestDashboardHistory(t *testing.T) {
+	d := New[string]()
+	d.Format = func(s string) string { return "<" + s + ">" }
+	srv := httptest.NewServer(d)
+	defer srv.Close()
+
+	// Non-finite statistics must not prevent events from being sent.
+	d.Observe(&evolve.PopulationStats[string]{Best: "a", BestFitness: math.Inf(1), Mean: math.NaN(), Generation: 0})
+	d.Observe(&evolve.PopulationStats[string]{Best: "b", BestFitness: 1, Mean: math.Inf(-1), Generation: 1})
+	d.Observe(&evolve.PopulationStats[string]{Best: "c", BestFitness: 2, Mean: 1, Generation: 2})
+
+	resp, err := http.Get(srv.URL + "/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	s := bufio.NewScanner(resp.Body)
+	var events []map[string]any
+	for len(events) < 3 && s.Scan() {
+		if !strings.HasPrefix(s.Text(), "data: ") {
+			continue
+		}
+		var ev map[string]any
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(s.Text(), "data: ")), &ev); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, ev)
+	}
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+
+	if ev := events[0]; ev["BestFitness"] != nil || ev["Mean"] != nil {
+		t.Errorf("non-finite values should be null, got %v", ev)
+	}
+	if ev := events[1]; ev["BestFitness"] != 1.0 || ev["Mean"] != nil {
+		t.Errorf("got %v, want BestFitness 1 and null Mean", ev)
+	}
+
+	// Only the last event carries the best candidate.
+	for i, ev := range events[:2] {
+		if _, ok := ev["Best"]; ok {
+			t.Errorf("history event %d should not have a best candidate, got %v", i, ev["Best"])
+		}
+	}
+	if best := events[2]["Best"]; best != "<c>" {
+		t.Errorf("last event best = %v, want <c>", best)
+	}
+}
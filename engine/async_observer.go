@@ -0,0 +1,233 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/arl/evolve"
+)
+
+// A QueuePolicy defines what an AsyncObserver does when its queue is full.
+type QueuePolicy int
+
+const (
+	// Block blocks the engine until there's room in the queue.
+	Block QueuePolicy = iota
+
+	// DropNewest drops the incoming population statistics.
+	DropNewest
+
+	// DropOldest drops the oldest queued population statistics to make room
+	// for the incoming ones.
+	DropOldest
+
+	// Coalesce replaces the most recently queued population statistics with
+	// the incoming ones.
+	Coalesce
+)
+
+// An AsyncObserver is an Observer notifying a wrapped Observer asynchronously,
+// in a separate goroutine, so that a slow observer doesn't stall evolution.
+//
+// Population statistics are delivered in order, through a bounded queue. When
+// the queue is full, Policy decides whether the engine blocks or which
+// statistics get dropped. Whatever the policy, the last observed statistics,
+// those of the final generation, are always delivered before Flush returns.
+//
+// Note that the Best candidate and the HallOfFame in the delivered statistics
+// are shared with the engine, so they must not be modified by the wrapped
+// observer.
+type AsyncObserver[T any] struct {
+	// Observer is the wrapped observer. If it implements RestartObserver,
+	// restarts are notified asynchronously as well, in order with the
+	// population statistics. Restart notifications are never dropped, nor
+	// make room for other notifications, whatever the Policy.
+	Observer Observer[T]
+
+	// QueueSize is the maximum number of queued notifications. If 0, it
+	// defaults to 1.
+	QueueSize int
+
+	// Policy defines what to do when the queue is full.
+	Policy QueuePolicy
+
+	once    sync.Once
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []asyncEvent[T]
+	tail    *asyncEvent[T] // last dropped event, if it's the most recent
+	busy    bool
+	closed  bool
+	dropped int
+	done    chan struct{}
+}
+
+type asyncEvent[T any] struct {
+	stats   evolve.PopulationStats[T]
+	restart bool
+}
+
+// NewAsyncObserver returns an AsyncObserver notifying obs through a queue of
+// the given size, handled according to policy.
+func NewAsyncObserver[T any](obs Observer[T], size int, policy QueuePolicy) *AsyncObserver[T] {
+	return &AsyncObserver[T]{Observer: obs, QueueSize: size, Policy: policy}
+}
+
+func (o *AsyncObserver[T]) start() {
+	o.once.Do(func() {
+		if o.QueueSize <= 0 {
+			o.QueueSize = 1
+		}
+		o.cond = sync.NewCond(&o.mu)
+		o.done = make(chan struct{})
+		go o.loop()
+	})
+}
+
+// Observe queues the population statistics for delivery to the wrapped
+// observer.
+func (o *AsyncObserver[T]) Observe(stats *evolve.PopulationStats[T]) {
+	o.push(asyncEvent[T]{stats: *stats})
+}
+
+// OnRestart queues the restart notification for delivery to the wrapped
+// observer, if it implements RestartObserver.
+func (o *AsyncObserver[T]) OnRestart(stats *evolve.PopulationStats[T]) {
+	if _, ok := o.Observer.(RestartObserver[T]); ok {
+		o.push(asyncEvent[T]{stats: *stats, restart: true})
+	}
+}
+
+func (o *AsyncObserver[T]) push(ev asyncEvent[T]) {
+	o.start()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.tail != nil && ev.restart {
+		// The dropped statistics preceding the restart must be delivered
+		// before it.
+		o.queue = append(o.queue, *o.tail)
+		o.dropped--
+	}
+	o.tail = nil
+	if len(o.queue) >= o.QueueSize && !ev.restart {
+		switch o.Policy {
+		case Block:
+			for len(o.queue) >= o.QueueSize {
+				o.cond.Wait()
+			}
+		case DropNewest:
+			o.dropped++
+			o.tail = &ev
+			return
+		case DropOldest:
+			if i := o.droppable(0, 1); i >= 0 {
+				o.dropped++
+				o.queue = append(o.queue[:i], o.queue[i+1:]...)
+			}
+		case Coalesce:
+			if i := o.droppable(len(o.queue)-1, -1); i == len(o.queue)-1 {
+				o.dropped++
+				o.queue = o.queue[:i]
+			}
+		}
+	}
+	o.queue = append(o.queue, ev)
+	o.cond.Broadcast()
+}
+
+// droppable returns the index of the first queued population statistics,
+// starting from index i and moving by step, or -1 if there's none. o.mu must be
+// held.
+func (o *AsyncObserver[T]) droppable(i, step int) int {
+	for ; i >= 0 && i < len(o.queue); i += step {
+		if !o.queue[i].restart {
+			return i
+		}
+	}
+	return -1
+}
+
+// loop delivers queued events until the observer is closed.
+func (o *AsyncObserver[T]) loop() {
+	defer close(o.done)
+
+	for {
+		o.mu.Lock()
+		for len(o.queue) == 0 && !o.closed {
+			o.cond.Wait()
+		}
+		if len(o.queue) == 0 {
+			o.mu.Unlock()
+			return
+		}
+		ev := o.queue[0]
+		o.queue = append(o.queue[:0], o.queue[1:]...)
+		o.busy = true
+		o.cond.Broadcast()
+		o.mu.Unlock()
+
+		if ev.restart {
+			o.Observer.(RestartObserver[T]).OnRestart(&ev.stats)
+		} else {
+			o.Observer.Observe(&ev.stats)
+		}
+
+		o.mu.Lock()
+		o.busy = false
+		o.cond.Broadcast()
+		o.mu.Unlock()
+	}
+}
+
+// Flush blocks until all queued population statistics, including the last
+// observed ones even if they were dropped, have been delivered. If the wrapped
+// observer has a Flush method, it's then flushed as well.
+func (o *AsyncObserver[T]) Flush() error {
+	o.start()
+
+	o.mu.Lock()
+	if o.tail != nil {
+		o.queue = append(o.queue, *o.tail)
+		o.tail = nil
+		o.dropped--
+		o.cond.Broadcast()
+	}
+	for len(o.queue) > 0 || o.busy {
+		o.cond.Wait()
+	}
+	o.mu.Unlock()
+
+	if f, ok := o.Observer.(flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Close flushes the queue and stops the delivery goroutine. Observe must not
+// be called after Close.
+func (o *AsyncObserver[T]) Close() error {
+	o.Flush()
+
+	o.mu.Lock()
+	o.closed = true
+	o.cond.Broadcast()
+	o.mu.Unlock()
+
+	<-o.done
+	return nil
+}
+
+// Dropped returns the number of notifications that have been dropped so far.
+func (o *AsyncObserver[T]) Dropped() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.dropped
+}
+
+// flusher is implemented by observers buffering notifications or their output,
+// such as AsyncObserver or CSVLogger. The engine flushes them once evolution is
+// over.
+type flusher interface {
+	Flush() error
+}
@@ -0,0 +1,292 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+	"github.com/arl/evolve/selection"
+)
+
+// gatedObserver records the generations it observes. Each notification
+// signals entered then blocks until gate is closed.
+type gatedObserver struct {
+	entered chan struct{}
+	gate    chan struct{}
+
+	mu   sync.Mutex
+	gens []int
+}
+
+func newGatedObserver() *gatedObserver {
+	return &gatedObserver{
+		entered: make(chan struct{}, 100),
+		gate:    make(chan struct{}),
+	}
+}
+
+func (o *gatedObserver) Observe(stats *evolve.PopulationStats[int]) {
+	o.entered <- struct{}{}
+	<-o.gate
+	o.mu.Lock()
+	o.gens = append(o.gens, stats.Generation)
+	o.mu.Unlock()
+}
+
+func (o *gatedObserver) generations() []int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]int(nil), o.gens...)
+}
+
+func TestAsyncObserverPolicies(t *testing.T) {
+	tests := []struct {
+		policy  QueuePolicy
+		want    []int
+		dropped int
+	}{
+		// Generation 0 is being delivered while the following ones fill the
+		// queue of size 2.
+		{Block, []int{0, 1, 2, 3, 4, 5}, 0},
+		{DropNewest, []int{0, 1, 2, 5}, 2},
+		{DropOldest, []int{0, 4, 5}, 3},
+		{Coalesce, []int{0, 1, 5}, 3},
+	}
+	for _, tt := range tests {
+		obs := newGatedObserver()
+		async := NewAsyncObserver[int](obs, 2, tt.policy)
+
+		async.Observe(&evolve.PopulationStats[int]{Generation: 0})
+		<-obs.entered
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for gen := 1; gen < 6; gen++ {
+				async.Observe(&evolve.PopulationStats[int]{Generation: gen})
+			}
+		}()
+
+		if tt.policy != Block {
+			// Non-blocking policies never stall the caller.
+			<-done
+		}
+		close(obs.gate)
+		<-done
+		check(t, async.Close())
+
+		got := obs.generations()
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("policy %d: got generations %v, want %v", tt.policy, got, tt.want)
+		}
+		if d := async.Dropped(); d != tt.dropped {
+			t.Errorf("policy %d: dropped %d, want %d", tt.policy, d, tt.dropped)
+		}
+	}
+}
+
+// openGate is a condition, never satisfied, that opens the gate of a
+// gatedObserver once a given generation has been reached.
+type openGate struct {
+	obs *gatedObserver
+	gen int
+}
+
+func (c openGate) IsSatisfied(stats *evolve.PopulationStats[int]) bool {
+	if stats.Generation == c.gen {
+		close(c.obs.gate)
+	}
+	return false
+}
+
+func (openGate) String() string { return "open gate" }
+
+func TestEngineAsyncObservers(t *testing.T) {
+	// The observer blocks until the last generation has been reached, which
+	// would stall the engine with synchronous dispatch.
+	obs := newGatedObserver()
+	eng := Engine[int]{
+		Factory: evolve.FactoryFunc[int](func(rng *rand.Rand) int {
+			return rng.Intn(100)
+		}),
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		EndConditions: []evolve.Condition[int]{
+			openGate{obs: obs, gen: 19},
+			condition.GenerationCount[int](20),
+		},
+		Observers:      []Observer[int]{obs},
+		ObserverQueue:  1,
+		ObserverPolicy: DropNewest,
+		Seed:           1,
+	}
+
+	_, err := eng.Run(10)
+	check(t, err)
+
+	// Run must only return once the final generation has been delivered.
+	gens := obs.generations()
+	if len(gens) < 2 || gens[0] != 0 || gens[len(gens)-1] != 19 {
+		t.Errorf("got generations %v, want first generation 0 and final generation 19", gens)
+	}
+	for i := 1; i < len(gens); i++ {
+		if gens[i] <= gens[i-1] {
+			t.Errorf("generations delivered out of order: %v", gens)
+			break
+		}
+	}
+}
+
+// eventRecorder records the generations it observes, and the restarts, as
+// 'R' followed by the generation that triggered them.
+type eventRecorder struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (r *eventRecorder) Observe(stats *evolve.PopulationStats[int]) {
+	r.mu.Lock()
+	r.events = append(r.events, fmt.Sprint(stats.Generation))
+	r.mu.Unlock()
+}
+
+func (r *eventRecorder) OnRestart(stats *evolve.PopulationStats[int]) {
+	r.mu.Lock()
+	r.events = append(r.events, fmt.Sprint("R", stats.Generation))
+	r.mu.Unlock()
+}
+
+// slowRecorder is an eventRecorder taking its time to observe generations, and
+// recording the end of the evolution as 'E'.
+type slowRecorder struct {
+	eventRecorder
+}
+
+func (r *slowRecorder) Observe(stats *evolve.PopulationStats[int]) {
+	time.Sleep(time.Millisecond)
+	r.eventRecorder.Observe(stats)
+}
+
+func (r *slowRecorder) OnEnd(*Result[int]) {
+	r.mu.Lock()
+	r.events = append(r.events, "E")
+	r.mu.Unlock()
+}
+
+func TestEngineAsyncEndOrder(t *testing.T) {
+	rec := &slowRecorder{}
+	eng := Engine[int]{
+		Factory: evolve.FactoryFunc[int](func(rng *rand.Rand) int {
+			return rng.Intn(100)
+		}),
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](10),
+		},
+		Observers:      []Observer[int]{rec},
+		ObserverQueue:  10,
+		ObserverPolicy: Block,
+		Seed:           1,
+	}
+
+	_, err := eng.Run(10)
+	check(t, err)
+
+	// The end of the evolution is notified once all the queued generations
+	// have been delivered.
+	want := "[0 1 2 3 4 5 6 7 8 9 E]"
+	if got := fmt.Sprint(rec.events); got != want {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
+
+// gatedRecorder is an eventRecorder whose first notification signals entered
+// then blocks until gate is closed.
+type gatedRecorder struct {
+	eventRecorder
+	entered, gate chan struct{}
+	once          sync.Once
+}
+
+func (r *gatedRecorder) Observe(stats *evolve.PopulationStats[int]) {
+	r.once.Do(func() {
+		r.entered <- struct{}{}
+		<-r.gate
+	})
+	r.eventRecorder.Observe(stats)
+}
+
+func TestEngineAsyncRestartOrder(t *testing.T) {
+	rec := &eventRecorder{}
+	eng := Engine[int]{
+		Factory: evolve.FactoryFunc[int](func(rng *rand.Rand) int {
+			return rng.Intn(100)
+		}),
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](10),
+		},
+		RestartConditions: []evolve.Condition[int]{
+			&condition.Stagnation[int]{Generations: 2},
+		},
+		Observers:      []Observer[int]{rec},
+		ObserverQueue:  1,
+		ObserverPolicy: Block,
+		RNG:            rand.New(rand.NewSource(99)),
+	}
+
+	_, err := eng.Run(10)
+	check(t, err)
+
+	// Restarts are delivered after the generation that triggered them, and
+	// before the next one.
+	want := "[0 1 2 R2 3 4 5 R5 6 7 8 R8 9]"
+	if got := fmt.Sprint(rec.events); got != want {
+		t.Errorf("got events %v, want %v", got, want)
+	}
+}
+
+func TestAsyncObserverRestartNotDropped(t *testing.T) {
+	for _, policy := range []QueuePolicy{DropNewest, DropOldest, Coalesce} {
+		rec := &gatedRecorder{entered: make(chan struct{}), gate: make(chan struct{})}
+		async := NewAsyncObserver[int](rec, 1, policy)
+
+		// Generation 0 is being delivered while the following notifications
+		// fill the queue of size 1.
+		async.Observe(&evolve.PopulationStats[int]{Generation: 0})
+		<-rec.entered
+		async.Observe(&evolve.PopulationStats[int]{Generation: 1})
+		async.OnRestart(&evolve.PopulationStats[int]{Generation: 1})
+		async.Observe(&evolve.PopulationStats[int]{Generation: 2})
+		async.Observe(&evolve.PopulationStats[int]{Generation: 3})
+		close(rec.gate)
+		check(t, async.Close())
+
+		events := fmt.Sprint(rec.events)
+		if !strings.Contains(events, "R1") {
+			t.Errorf("policy %d: restart dropped, got events %v", policy, events)
+		}
+		if !strings.HasSuffix(events, "3]") {
+			t.Errorf("policy %d: last generation dropped, got events %v", policy, events)
+		}
+	}
+}
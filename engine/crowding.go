@@ -0,0 +1,141 @@
+package engine
+
+import (
+	"math/rand"
+	"runtime"
+
+	"github.com/arl/evolve"
+)
+
+// Crowding implements crowding replacement, a niching evolutionary algorithm
+// in which offspring compete against their most similar parent for a slot in
+// the next generation.
+//
+// At each epoch, the population is randomly paired. Each pair of parents is
+// given to the operator, that must return a pair of offspring. Each child is
+// then matched with the closest of both parents, according to Distance, and
+// competes against it for survival. Since replacement only happens between
+// similar individuals, distinct niches of the search space are preserved,
+// allowing multiple optima to survive in the final population.
+type Crowding[T any] struct {
+	// Operator produces offspring from a pair of parents. It is applied on
+	// each pair of parents in isolation, and must return exactly 2 offspring.
+	// It would typically be a crossover operator, or a pipeline starting with
+	// a crossover.
+	Operator  evolve.Operator[T]
+	Evaluator evolve.Evaluator[T]
+
+	// Distance returns the distance between 2 candidates, that is how
+	// dissimilar they are. It is used to match children with their most
+	// similar parent.
+	Distance func(a, b T) float64
+
+	// Probabilistic selects the replacement scheme. If false, deterministic
+	// crowding is used: a child replaces the parent it competes with if it's
+	// strictly fitter. If true, probabilistic crowding is used: the child
+	// replaces the parent with a probability proportional to its fitness
+	// relatively to the fitness of both.
+	Probabilistic bool
+
+	// Number of concurrent processes to use (defaults to the number of cores).
+	Concurrency int
+
+	init  bool
+	evals int
+}
+
+// Epoch performs a single step/iteration of the evolutionary process.
+//
+// pop is the population to evolve, sorted by fitness, the fittest first.
+//
+// Returns the updated population after the evolutionary process has proceeded
+// by one step/iteration.
+func (e *Crowding[T]) Epoch(pop *evolve.Population[T], rng *rand.Rand) *evolve.Population[T] {
+	if !e.init {
+		if e.Concurrency == 0 {
+			e.Concurrency = runtime.NumCPU()
+		}
+		e.init = true
+	}
+
+	// Pair parents randomly.
+	idx := rng.Perm(pop.Len())
+	npairs := pop.Len() / 2
+
+	children := make([]T, 0, 2*npairs)
+	for i := 0; i < npairs; i++ {
+		p1, p2 := pop.Candidates[idx[2*i]], pop.Candidates[idx[2*i+1]]
+		off := e.Operator.Apply([]T{p1, p2}, rng)
+		if len(off) != 2 {
+			panic("crowding operator must produce 2 offspring from 2 parents")
+		}
+		children = append(children, off...)
+	}
+	evchildren := evolve.EvaluatePopulation(children, e.Evaluator, e.Concurrency)
+	e.evals = evchildren.Len()
+
+	next := evolve.NewPopulation[T](pop.Len())
+	for i := 0; i < npairs; i++ {
+		ip1, ip2 := idx[2*i], idx[2*i+1]
+		ic1, ic2 := 2*i, 2*i+1
+
+		p1, p2 := pop.Candidates[ip1], pop.Candidates[ip2]
+		c1, c2 := evchildren.Candidates[ic1], evchildren.Candidates[ic2]
+
+		// Match each child with its closest parent.
+		if e.Distance(p1, c1)+e.Distance(p2, c2) > e.Distance(p1, c2)+e.Distance(p2, c1) {
+			ic1, ic2 = ic2, ic1
+		}
+
+		e.compete(next, ip1, pop, ip1, evchildren, ic1, rng)
+		e.compete(next, ip2, pop, ip2, evchildren, ic2, rng)
+	}
+
+	// With an odd population size, the unpaired candidate survives unchanged.
+	if pop.Len()%2 != 0 {
+		last := idx[pop.Len()-1]
+		next.Candidates[last] = pop.Candidates[last]
+		next.Fitness[last] = pop.Fitness[last]
+	}
+	return next
+}
+
+// compete makes the child at index ic of children compete with the parent at
+// index ip of parents, and places the winner at index i of next.
+func (e *Crowding[T]) compete(next *evolve.Population[T], i int, parents *evolve.Population[T], ip int, children *evolve.Population[T], ic int, rng *rand.Rand) {
+	fp, fc := parents.Fitness[ip], children.Fitness[ic]
+	natural := e.Evaluator.IsNatural()
+
+	var childWins bool
+	if e.Probabilistic {
+		// Probability for the child to win the competition.
+		p := 0.5
+		if sum := fc + fp; sum != 0 {
+			if natural {
+				p = fc / sum
+			} else {
+				p = fp / sum
+			}
+		}
+		childWins = rng.Float64() < p
+	} else {
+		if natural {
+			childWins = fc > fp
+		} else {
+			childWins = fc < fp
+		}
+	}
+
+	if childWins {
+		next.Candidates[i] = children.Candidates[ic]
+		next.Fitness[i] = fc
+	} else {
+		next.Candidates[i] = parents.Candidates[ip]
+		next.Fitness[i] = fp
+	}
+}
+
+// Evaluations returns the number of candidates evaluated during the last call
+// to Epoch. With an odd population size, the unpaired candidate isn't
+// evaluated again.
+func (e *Crowding[T]) Evaluations() int { return e.evals }
@@ -0,0 +1,98 @@
+package engine
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+)
+
+// Bimodal fitness function, with 2 peaks of the same height at x=20 and x=80.
+var bimodalEvaluator = evolve.EvaluatorFunc(true, func(x float64, _ []float64) float64 {
+	return math.Max(
+		100-math.Abs(x-20),
+		100-math.Abs(x-80),
+	)
+})
+
+// gaussianStep is an operator that mutates all candidates by adding a small
+// normally distributed value.
+type gaussianStep float64
+
+func (s gaussianStep) Apply(sel []float64, rng *rand.Rand) []float64 {
+	res := make([]float64, len(sel))
+	for i := range sel {
+		res[i] = math.Min(100, math.Max(0, sel[i]+rng.NormFloat64()*float64(s)))
+	}
+	return res
+}
+
+func TestCrowdingPreservesNiches(t *testing.T) {
+	for _, probabilistic := range []bool{false, true} {
+		rng := rand.New(rand.NewSource(99))
+		eng := Engine[float64]{
+			Factory: evolve.FactoryFunc[float64](func(rng *rand.Rand) float64 {
+				return rng.Float64() * 100
+			}),
+			Evaluator: bimodalEvaluator,
+			Epocher: &Crowding[float64]{
+				Operator:      gaussianStep(2),
+				Evaluator:     bimodalEvaluator,
+				Distance:      func(a, b float64) float64 { return math.Abs(a - b) },
+				Probabilistic: probabilistic,
+			},
+			EndConditions: []evolve.Condition[float64]{
+				condition.GenerationCount[float64](100),
+			},
+			RNG: rng,
+		}
+
+		pop, _, err := eng.Evolve(51)
+		check(t, err)
+		if pop.Len() != 51 {
+			t.Fatalf("probabilistic=%t: population size = %d, want 51", probabilistic, pop.Len())
+		}
+
+		// Both peaks must be populated.
+		var low, high int
+		for _, x := range pop.Candidates {
+			switch {
+			case math.Abs(x-20) < 10:
+				low++
+			case math.Abs(x-80) < 10:
+				high++
+			}
+		}
+		if low == 0 || high == 0 {
+			t.Errorf("probabilistic=%t: niche lost, %d candidates around 20, %d around 80", probabilistic, low, high)
+		}
+	}
+}
+
+func TestCrowdingDeterministicReplacement(t *testing.T) {
+	// With deterministic crowding, the population can't regress: each slot
+	// is only ever replaced by a fitter candidate.
+	pop := evolve.NewPopulation[float64](4)
+	copy(pop.Candidates, []float64{20, 80, 50, 10})
+	for i, x := range pop.Candidates {
+		pop.Fitness[i] = bimodalEvaluator.Fitness(x, pop.Candidates)
+	}
+
+	rng := rand.New(rand.NewSource(99))
+	e := &Crowding[float64]{
+		Operator:  gaussianStep(30),
+		Evaluator: bimodalEvaluator,
+		Distance:  func(a, b float64) float64 { return math.Abs(a - b) },
+	}
+	for gen := 0; gen < 20; gen++ {
+		next := e.Epoch(pop, rng)
+		for i := range pop.Fitness {
+			if next.Fitness[i] < pop.Fitness[i] {
+				t.Fatalf("gen %d: slot %d regressed from %v to %v", gen, i, pop.Fitness[i], next.Fitness[i])
+			}
+		}
+		pop = next
+	}
+}
@@ -3,11 +3,13 @@ package engine
 import (
 	"errors"
 	"math/rand"
+	"reflect"
 	"runtime"
 	"sort"
 	"time"
 
 	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
 	"github.com/arl/evolve/pkg/mt19937"
 )
 
@@ -24,22 +26,88 @@ type Engine[T any] struct {
 
 	EndConditions []evolve.Condition[T]
 
+	// RestartConditions are the conditions triggering a restart of the
+	// evolution, for example when it stagnates or when the population has lost
+	// its diversity. On restart, the population is reinitialized with the
+	// Factory, and evolution continues until one of the EndConditions is met.
+	//
+	// After a restart, the restart conditions and the Epocher that implement
+	// a Reset method are reset.
+	RestartConditions []evolve.Condition[T]
+
+	// RestartElites is the number of fittest candidates found so far, over
+	// all restarts, that are seeded into restarted populations. They are
+	// taken from HallOfFame if set, or from an internal hall of fame
+	// otherwise.
+	RestartElites int
+
+	// RestartGrowth is the factor by which the population size is multiplied
+	// at each restart, as in IPOP (increasing population size) restart
+	// strategies. Values lower or equal to 1 leave the population size
+	// unchanged.
+	RestartGrowth float64
+
+	// RestartBIPOP enables a BIPOP-like restart strategy, in which restarts
+	// alternate between 2 regimes: a large population regime in which the
+	// population size increases by RestartGrowth, and a small population
+	// regime in which the population size is randomly chosen between the
+	// initial population size and half of the largest population size so far.
+	RestartBIPOP bool
+
+	// HallOfFame, if set, keeps track of the best individuals ever seen
+	// during evolution. Its members are reported in the population
+	// statistics, and its Elites fittest members are re-injected into each new
+	// generation. Note that it's not cleared between calls to Evolve.
+	HallOfFame *evolve.HallOfFame[T]
+
 	// Observers of the evolution process.
 	Observers []Observer[T]
 
+	// ObserverQueue, if positive, makes the engine notify observers
+	// asynchronously, so that slow observers don't stall evolution. Each
+	// observer is then wrapped into an AsyncObserver, with a queue of that
+	// size handled according to ObserverPolicy. Whatever the dispatch mode,
+	// observers having a Flush method are flushed before Run returns. Lifecycle
+	// hooks, such as StartObserver, are still called synchronously.
+	ObserverQueue int
+
+	// ObserverPolicy defines what to do when the queue of an asynchronous
+	// observer is full. It's only used if ObserverQueue is positive.
+	ObserverPolicy QueuePolicy
+
 	// Seeds provides the engine with a set of candidates to seed the starting
 	// population with. Successive calls to Seeds will replace the set of seed
 	// candidates set in the previous call.
 	Seeds []T
 
 	// RNG is the source of randomness of the engine. If nil, it's set to a
-	// mt19937 pseudo random number generator.
+	// mt19937 pseudo random number generator, seeded with Seed.
 	RNG *rand.Rand
 
+	// Seed is the seed of the default RNG, only used if RNG is nil. If 0, a
+	// time-based seed is used.
+	Seed int64
+
+	// HistoryInterval is the interval, in generations, at which population
+	// statistics are recorded in the history of the Result returned by Run.
+	// If 0, statistics are recorded at every generation. If negative, no
+	// history is recorded. Statistics of the final generation are always
+	// recorded, unless history is disabled.
+	HistoryInterval int
+
+	// Clock, if set, is advanced by the engine at the start of each
+	// generation, to the generation index or to the number of evaluations
+	// performed so far, depending on its unit. It drives generator schedules.
+	Clock *generator.Clock
+
 	// Number of concurrent processes to use (defaults to the number of cores).
 	Concurrency int
 
-	stats *evolve.Dataset
+	stats     *evolve.Dataset
+	restarts  int
+	evals     int
+	fame      *evolve.HallOfFame[T]
+	observers []Observer[T]
 }
 
 // AddObserver adds an observer of the evolution process.
@@ -59,7 +127,8 @@ func (e *Engine[T]) RemoveObserver(o Observer[T]) {
 
 // Evolve runs the evolutionary algorithm until one of the termination
 // conditions is met, then return the entire population present during the final
-// generation.
+// generation. The best individuals seen during the whole evolution can be
+// retrieved from HallOfFame.
 //
 // size is the number of candidate in the population. They whole population is
 // generated for the first generation, unless some seed candidates are provided
@@ -67,12 +136,24 @@ func (e *Engine[T]) RemoveObserver(o Observer[T]) {
 //
 // At least one termination condition must be defined with EndOn, or Evolve will
 // return an error.
+//
+// See Run in order to get more information about the evolution.
 func (e *Engine[T]) Evolve(popsize int) (*evolve.Population[T], []evolve.Condition[T], error) {
+	res, err := e.Run(popsize)
+	if err != nil {
+		return nil, nil, err
+	}
+	return res.Population, res.Satisfied, nil
+}
+
+// Run runs the evolutionary algorithm, as Evolve does, but returns a Result
+// describing the whole evolution run.
+func (e *Engine[T]) Run(popsize int) (*Result[T], error) {
 	if popsize <= 0 {
-		return nil, nil, errors.New("invalid population size")
+		return nil, errors.New("invalid population size")
 	}
 	if len(e.EndConditions) == 0 {
-		return nil, nil, errors.New("no termination condition specified")
+		return nil, errors.New("no termination condition specified")
 	}
 
 	if e.Concurrency == 0 {
@@ -80,45 +161,142 @@ func (e *Engine[T]) Evolve(popsize int) (*evolve.Population[T], []evolve.Conditi
 	}
 
 	if e.RNG == nil {
-		seed := time.Now().UnixNano()
-		e.RNG = rand.New(mt19937.New(seed))
+		if e.Seed == 0 {
+			e.Seed = time.Now().UnixNano()
+		}
+		e.RNG = rand.New(mt19937.New(e.Seed))
 	}
 
 	// Track down evolution stats in a dataset.
 	e.stats = evolve.NewDataset(popsize)
+	e.restarts = 0
+	e.evals = 0
+
+	e.observers = e.Observers
+	if e.ObserverQueue > 0 {
+		e.observers = make([]Observer[T], len(e.Observers))
+		for i, o := range e.Observers {
+			e.observers[i] = NewAsyncObserver(o, e.ObserverQueue, e.ObserverPolicy)
+		}
+	}
 
 	var ngen int
 	start := time.Now()
+	res := &Result[T]{Seed: e.Seed}
+
+	// Lifecycle hooks are implemented by the observers themselves, not by the
+	// asynchronous wrappers.
+	h := hooks[T](e.Observers)
+	if hs, ok := e.Epocher.(hookSetter[T]); ok {
+		hs.setHooks(h)
+	}
+	h.start(e, popsize)
+	e.tick(0)
+	h.generationStart(0)
 
 	pop := evolve.SeedPopulation(e.Factory, popsize, e.Seeds, e.RNG)
 
-	var satisfied []evolve.Condition[T]
+	// Keep track of the best candidates found so far, to seed restarted
+	// populations with.
+	e.fame = e.HallOfFame
+	if e.fame == nil && len(e.RestartConditions) != 0 {
+		e.fame = &evolve.HallOfFame[T]{Size: e.RestartElites}
+	}
+	initsize, largest := popsize, popsize
 
 	// Evaluate initial population fitness
 	evpop := evolve.EvaluatePopulation(pop, e.Evaluator, e.Concurrency)
+	e.evals += evpop.Len()
 	for {
+		h.evaluated(evpop)
+
 		// Sort population according to fitness.
-		if e.Evaluator.IsNatural() {
-			sort.Sort(sort.Reverse(evpop))
-		} else {
-			sort.Sort(evpop)
+		sortPopulation(evpop, e.Evaluator.IsNatural())
+
+		if e.fame != nil {
+			e.fame.Update(evpop, e.Evaluator.IsNatural())
 		}
 
 		// compute population stats
 		data := e.updateStats(evpop, ngen, time.Since(start))
 
 		// check for termination conditions
-		satisfied = satisfiedConditions(data, e.EndConditions)
-		if satisfied != nil {
+		res.Satisfied = satisfiedConditions(data, e.EndConditions)
+		e.record(res, data, res.Satisfied != nil)
+		if res.Satisfied != nil {
 			break
 		}
 
-		// perform evolution
-		evpop = e.Epocher.Epoch(evpop, e.RNG)
+		restart := satisfiedConditions(data, e.RestartConditions) != nil
+		if restart {
+			popsize, largest = e.restartSize(initsize, largest)
+			e.restarts++
+			e.notifyRestart(data)
+		}
 
 		ngen++
+		e.tick(ngen)
+		h.generationStart(ngen)
+
+		if restart {
+			// Restart evolution with a new population.
+			seeds := e.fame.Population().Candidates
+			if len(seeds) > e.RestartElites {
+				seeds = seeds[:e.RestartElites]
+			}
+			pop = evolve.SeedPopulation(e.Factory, popsize, seeds, e.RNG)
+			evpop = evolve.EvaluatePopulation(pop, e.Evaluator, e.Concurrency)
+			e.evals += evpop.Len()
+		} else {
+			// perform evolution
+			evpop = e.Epocher.Epoch(evpop, e.RNG)
+			if ec, ok := e.Epocher.(EvaluationCounter); ok {
+				e.evals += ec.Evaluations()
+			} else {
+				e.evals += evpop.Len()
+			}
+			if e.HallOfFame != nil && e.HallOfFame.Elites > 0 {
+				e.injectFame(evpop)
+			}
+		}
+	}
+
+	res.Population = evpop
+	res.Generations = ngen + 1
+	res.Elapsed = time.Since(start)
+
+	// Deliver pending notifications before notifying the end of the evolution.
+	e.flushObservers()
+	h.end(res)
+	return res, nil
+}
+
+// tick advances the clock, if any, to the start of generation gen.
+func (e *Engine[T]) tick(gen int) {
+	if e.Clock != nil {
+		e.Clock.Tick(gen, e.evals)
+	}
+}
+
+// record records the population statistics of a generation into res.
+func (e *Engine[T]) record(res *Result[T], stats *evolve.PopulationStats[T], final bool) {
+	better := stats.BestFitness > res.BestFitness
+	if !stats.Natural {
+		better = stats.BestFitness < res.BestFitness
+	}
+	if stats.Generation == 0 || better {
+		res.Best = stats.Best
+		res.BestFitness = stats.BestFitness
+		res.BestGeneration = stats.Generation
+	}
+	res.Evaluations = stats.Evaluations
+
+	if e.HistoryInterval < 0 {
+		return
+	}
+	if final || e.HistoryInterval == 0 || stats.Generation%e.HistoryInterval == 0 {
+		res.History = append(res.History, *stats)
 	}
-	return evpop, satisfied, nil
 }
 
 func (e *Engine[T]) updateStats(pop *evolve.Population[T], ngen int, elapsed time.Duration) *evolve.PopulationStats[T] {
@@ -137,14 +315,108 @@ func (e *Engine[T]) updateStats(pop *evolve.Population[T], ngen int, elapsed tim
 		Size:        e.stats.Len(),
 		Generation:  ngen,
 		Elapsed:     elapsed,
+		Evaluations: e.evals,
+		Restarts:    e.restarts,
+	}
+	if e.fame != nil {
+		stats.HallOfFame = e.fame.Population()
+	}
+
+	if a, ok := e.Epocher.(StatsAnnotator[T]); ok {
+		a.AnnotateStats(&stats)
 	}
 
-	for _, o := range e.Observers {
+	for _, o := range e.observers {
 		o.Observe(&stats)
 	}
 	return &stats
 }
 
+// flushObservers flushes the observers having a Flush method, and stops the
+// asynchronous observers created by the engine.
+func (e *Engine[T]) flushObservers() {
+	for _, o := range e.observers {
+		if e.ObserverQueue > 0 {
+			o.(*AsyncObserver[T]).Close()
+			continue
+		}
+		if f, ok := o.(flusher); ok {
+			f.Flush()
+		}
+	}
+}
+
+// injectFame replaces the least fit candidates of pop with the fittest
+// members of the hall of fame that are not already part of pop.
+func (e *Engine[T]) injectFame(pop *evolve.Population[T]) {
+	sortPopulation(pop, e.Evaluator.IsNatural())
+
+	var present map[any]struct{}
+	if e.HallOfFame.Key != nil {
+		present = make(map[any]struct{}, pop.Len())
+		for _, cand := range pop.Candidates {
+			present[e.HallOfFame.Key(cand)] = struct{}{}
+		}
+	}
+	isPresent := func(member T) bool {
+		if present != nil {
+			_, ok := present[e.HallOfFame.Key(member)]
+			return ok
+		}
+		for _, cand := range pop.Candidates {
+			if reflect.DeepEqual(cand, member) {
+				return true
+			}
+		}
+		return false
+	}
+
+	members := e.HallOfFame.Population()
+	j := pop.Len() - 1
+	for i := 0; i < members.Len() && i < e.HallOfFame.Elites && j >= 0; i++ {
+		if isPresent(members.Candidates[i]) {
+			continue
+		}
+		pop.Candidates[j] = members.Candidates[i]
+		pop.Fitness[j] = members.Fitness[i]
+		j--
+	}
+}
+
+// sortPopulation sorts pop according to fitness, the fittest first.
+func sortPopulation[T any](pop *evolve.Population[T], natural bool) {
+	if natural {
+		sort.Sort(sort.Reverse(pop))
+	} else {
+		sort.Sort(pop)
+	}
+}
+
+// A StatsAnnotator is an Epocher that reports additional statistics about the
+// population it evolves, or about its internal state.
+//
+// If the engine Epocher implements StatsAnnotator, AnnotateStats is called
+// once per generation, after the engine has computed population statistics and
+// before observers get notified.
+type StatsAnnotator[T any] interface {
+	AnnotateStats(*evolve.PopulationStats[T])
+}
+
+// An EvaluationCounter is an Epocher that reports the number of fitness
+// evaluations it performs, for when it doesn't match the size of the
+// population returned by Epoch. Generational also counts the evaluations
+// reported by its operator if it implements EvaluationCounter, in which case
+// Evaluations reports those performed during the last call to Apply.
+//
+// If the engine Epocher implements EvaluationCounter, Evaluations is called
+// after each call to Epoch. Otherwise, all the candidates returned by Epoch are
+// considered to have been evaluated.
+type EvaluationCounter interface {
+	// Evaluations returns the number of fitness evaluations performed during
+	// the last call to Epoch.
+	Evaluations() int
+}
+
 // satisfiedConditions returns the satisfied conditions, or nil if none of them are.
 func satisfiedConditions[T any](stats *evolve.PopulationStats[T], conds []evolve.Condition[T]) []evolve.Condition[T] {
 	var c []evolve.Condition[T]
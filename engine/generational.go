@@ -2,6 +2,7 @@ package engine
 
 import (
 	"math/rand"
+	"reflect"
 	"runtime"
 
 	"github.com/arl/evolve"
@@ -28,9 +29,13 @@ type Generational[T any] struct {
 	// Number of concurrent processes to use (defaults to the number of cores).
 	Concurrency int
 
-	init bool
+	init  bool
+	evals int
+	hooks hooks[T]
 }
 
+func (e *Generational[T]) setHooks(h hooks[T]) { e.hooks = h }
+
 // Epoch performs a single step/iteration of the evolutionary process.
 //
 // pop is the population to evolve, sorted by fitness, the fittest first.
@@ -56,11 +61,109 @@ func (e *Generational[T]) Epoch(pop *evolve.Population[T], rng *rand.Rand) *evol
 
 	// Select the rest of population through natural selection.
 	selected := e.Selection.Select(pop, e.Evaluator.IsNatural(), pop.Len()-e.Elites, rng)
+	e.hooks.selected(selected)
 
 	// Apply genetic operators on the selected candidates.
 	nextpop = e.Operator.Apply(append(nextpop, selected...), rng)
+	e.hooks.offspring(nextpop)
+
+	noff := len(nextpop)
 
 	// While the elites, if any, are added, untouched, to the next population.
 	nextpop = append(nextpop, elite...)
-	return evolve.EvaluatePopulation(nextpop, e.Evaluator, e.Concurrency)
+	evpop := evolve.EvaluatePopulation(nextpop, e.Evaluator, e.Concurrency)
+	e.evals = evpop.Len()
+	if ec, ok := e.Operator.(EvaluationCounter); ok {
+		// Operators evaluating candidates, such as operator.Instrumented.
+		e.evals += ec.Evaluations()
+	}
+
+	// Provide the parents and offspring fitness to operators learning from it.
+	if fo, ok := e.Operator.(evolve.FeedbackOperator[T]); ok {
+		fo.Feedback(&evolve.OperatorFeedback[T]{
+			Parents:          selected,
+			ParentFitness:    e.selectedFitness(pop, selected),
+			OffspringFitness: evpop.Fitness[:noff],
+			Natural:          e.Evaluator.IsNatural(),
+		})
+	}
+	return evpop
+}
+
+// selectedFitness returns the fitness of the selected candidates, looked up in
+// the population they have been selected from. Candidates that can't be found,
+// because they can't be identified, are evaluated.
+func (e *Generational[T]) selectedFitness(pop *evolve.Population[T], sel []T) []float64 {
+	index := make(map[any]int, pop.Len())
+	for i, c := range pop.Candidates {
+		if id, ok := identity(c); ok {
+			if _, dup := index[id]; !dup {
+				index[id] = i
+			}
+		}
+	}
+
+	fitness := make([]float64, len(sel))
+	for i, c := range sel {
+		if id, ok := identity(c); ok {
+			if j, ok := index[id]; ok {
+				fitness[i] = pop.Fitness[j]
+				continue
+			}
+		}
+		fitness[i] = e.Evaluator.Fitness(c, pop.Candidates)
+		e.evals++
+	}
+	return fitness
+}
+
+// Evaluations returns the number of candidates evaluated during the last call
+// to Epoch, including those evaluated by the operator, if it implements
+// EvaluationCounter, and the selected candidates whose fitness had to be
+// evaluated again for the operator feedback.
+func (e *Generational[T]) Evaluations() int { return e.evals }
+
+// sliceID identifies a slice by its type, backing array and length.
+type sliceID struct {
+	typ reflect.Type
+	ptr uintptr
+	len int
+}
+
+// identity returns a comparable value identifying the candidate c: c itself if
+// its type is comparable, or its address for slices and maps. ok is false if
+// c can't be identified.
+func identity(c any) (id any, ok bool) {
+	v := reflect.ValueOf(c)
+	switch v.Kind() {
+	case reflect.Invalid, reflect.Func:
+		return nil, false
+	case reflect.Slice:
+		return sliceID{typ: v.Type(), ptr: v.Pointer(), len: v.Len()}, true
+	case reflect.Map:
+		return sliceID{typ: v.Type(), ptr: v.Pointer()}, true
+	case reflect.Float32, reflect.Float64:
+		// NaN isn't equal to itself.
+		if f := v.Float(); f != f {
+			return nil, false
+		}
+	}
+	if !v.Type().Comparable() {
+		return nil, false
+	}
+	return c, true
+}
+
+// operatorStatser is implemented by operators measuring their contribution,
+// such as operator.Instrumented.
+type operatorStatser interface {
+	OperatorStats() []evolve.OperatorStats
+}
+
+// AnnotateStats reports the contribution statistics of the operators, if
+// Operator measures them.
+func (e *Generational[T]) AnnotateStats(stats *evolve.PopulationStats[T]) {
+	if op, ok := e.Operator.(operatorStatser); ok {
+		stats.Operators = op.OperatorStats()
+	}
 }
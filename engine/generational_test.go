@@ -202,3 +202,174 @@ func (s evaluator) Fitness(cand string, pop []string) float64 {
 // Fitness is not natural, one fitness point represents an error, so the lower
 // is better
 func (evaluator) IsNatural() bool { return false }
+
+func TestGenerationalOperatorStats(t *testing.T) {
+	var stats []evolve.OperatorStats
+	eng := Engine[int]{
+		Factory:   zeroFactory,
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			Operator: &operator.Instrumented[int]{
+				Pipeline:  operator.Pipeline[int]{zeroIntMaker{}},
+				Evaluator: intEvaluator{},
+				Names:     []string{"zero"},
+			},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		Seeds: []int{7, 11, 13},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](2),
+		},
+		Observers: []Observer[int]{
+			ObserverFunc(func(s *evolve.PopulationStats[int]) { stats = s.Operators }),
+		},
+	}
+	_, _, err := eng.Evolve(10)
+	check(t, err)
+
+	if len(stats) != 1 || stats[0].Name != "zero" || stats[0].Applied != 10 || stats[0].Improved != 0 {
+		t.Errorf("got operator stats %+v, want 10 applications of zero, without improvement", stats)
+	}
+}
+
+func TestGenerationalOperatorEvaluations(t *testing.T) {
+	var evals []int
+	eng := Engine[int]{
+		Factory:   zeroFactory,
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			Operator: &operator.Instrumented[int]{
+				Pipeline:  operator.Pipeline[int]{zeroIntMaker{}, zeroIntMaker{}, zeroIntMaker{}},
+				Evaluator: intEvaluator{},
+			},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](3),
+		},
+		Observers: []Observer[int]{
+			ObserverFunc(func(s *evolve.PopulationStats[int]) { evals = append(evals, s.Evaluations) }),
+		},
+	}
+	_, _, err := eng.Evolve(10)
+	check(t, err)
+
+	// Each generation, the offspring and the output of the 2 intermediate
+	// stages are evaluated.
+	if fmt.Sprint(evals) != "[10 40 70]" {
+		t.Errorf("got evaluations %v, want [10 40 70]", evals)
+	}
+}
+
+func TestEngineClock(t *testing.T) {
+	clock := &generator.Clock{Unit: generator.Evaluations}
+	var ticks []int
+	eng := Engine[int]{
+		Factory:   zeroFactory,
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](3),
+		},
+		Observers: []Observer[int]{
+			ObserverFunc(func(s *evolve.PopulationStats[int]) { ticks = append(ticks, clock.Now()) }),
+		},
+		Clock: clock,
+	}
+	_, _, err := eng.Evolve(10)
+	check(t, err)
+
+	// The clock is advanced at the start of each generation, to the number of
+	// evaluations performed so far.
+	if fmt.Sprint(ticks) != "[0 10 20]" {
+		t.Errorf("got clock ticks %v, want [0 10 20]", ticks)
+	}
+}
+
+// feedbackRecorder is a test operator incrementing the first gene of its
+// parents, and recording the feedback it receives.
+type feedbackRecorder struct {
+	fbs []*evolve.OperatorFeedback[[]int]
+}
+
+func (op *feedbackRecorder) Apply(sel [][]int, rng *rand.Rand) [][]int {
+	off := make([][]int, len(sel))
+	for i, c := range sel {
+		off[i] = append([]int{c[0] + 1}, c[1:]...)
+	}
+	return off
+}
+
+func (op *feedbackRecorder) Feedback(fb *evolve.OperatorFeedback[[]int]) {
+	// The feedback slices are only valid during the call.
+	cpy := *fb
+	cpy.Parents = append([][]int(nil), fb.Parents...)
+	cpy.ParentFitness = append([]float64(nil), fb.ParentFitness...)
+	cpy.OffspringFitness = append([]float64(nil), fb.OffspringFitness...)
+	op.fbs = append(op.fbs, &cpy)
+}
+
+// countingSumEvaluator is a natural evaluator summing genes, and counting its
+// calls.
+type countingSumEvaluator struct{ calls *int }
+
+func (e countingSumEvaluator) Fitness(c []int, pop [][]int) float64 {
+	*e.calls++
+	var sum int
+	for _, g := range c {
+		sum += g
+	}
+	return float64(sum)
+}
+
+func (countingSumEvaluator) IsNatural() bool { return true }
+
+func TestGenerationalFeedback(t *testing.T) {
+	var calls int
+	eval := countingSumEvaluator{calls: &calls}
+	op := &feedbackRecorder{}
+	eng := Engine[[]int]{
+		Factory: evolve.FactoryFunc[[]int](func(rng *rand.Rand) []int {
+			return []int{rng.Intn(10), rng.Intn(10)}
+		}),
+		Evaluator: eval,
+		Epocher: &Generational[[]int]{
+			Operator:  op,
+			Evaluator: eval,
+			Selection: selection.RouletteWheel[[]int]{},
+		},
+		EndConditions: []evolve.Condition[[]int]{
+			condition.GenerationCount[[]int](3),
+		},
+		Concurrency: 1,
+	}
+	_, _, err := eng.Evolve(10)
+	check(t, err)
+
+	if len(op.fbs) != 2 {
+		t.Fatalf("got %d feedbacks, want 2", len(op.fbs))
+	}
+	for _, fb := range op.fbs {
+		if len(fb.Parents) != 10 || len(fb.ParentFitness) != 10 || len(fb.OffspringFitness) != 10 || !fb.Natural {
+			t.Fatalf("got feedback %+v, want 10 parents and offspring, with natural fitness", fb)
+		}
+		for i := range fb.Parents {
+			if want := float64(fb.Parents[i][0] + fb.Parents[i][1]); fb.ParentFitness[i] != want {
+				t.Errorf("parent %v fitness = %v, want %v", fb.Parents[i], fb.ParentFitness[i], want)
+			}
+			if imp := fb.Improvement(i); imp != 1 {
+				t.Errorf("improvement of offspring %d = %v, want 1", i, imp)
+			}
+		}
+	}
+	// Parents fitness is looked up, not evaluated again.
+	if calls != 30 {
+		t.Errorf("got %d evaluations, want 30", calls)
+	}
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+	"github.com/arl/evolve/selection"
+)
+
+func TestEngineHallOfFame(t *testing.T) {
+	hof := &evolve.HallOfFame[int]{
+		Size: 3,
+		Key:  func(i int) any { return i },
+	}
+
+	var last *evolve.PopulationStats[int]
+	eng := Engine[int]{
+		Factory:   zeroFactory,
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			// Without elitism, seeds are lost after the first generation.
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		Seeds: []int{7, 11, 13, 13},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](3),
+		},
+		HallOfFame: hof,
+		Observers: []Observer[int]{ObserverFunc(func(stats *evolve.PopulationStats[int]) {
+			last = stats
+		})},
+		RNG: rand.New(rand.NewSource(99)),
+	}
+
+	pop, _, err := eng.Evolve(10)
+	check(t, err)
+
+	if pop.Fitness[0] != 0 {
+		t.Fatalf("best fitness = %v, want 0", pop.Fitness[0])
+	}
+	want := []int{13, 11, 7}
+	for i, cand := range hof.Population().Candidates {
+		if cand != want[i] {
+			t.Errorf("hall of fame = %v, want %v", hof.Population().Candidates, want)
+			break
+		}
+	}
+	if last.HallOfFame == nil || last.HallOfFame.Len() != 3 {
+		t.Errorf("stats.HallOfFame = %v, want 3 members", last.HallOfFame)
+	}
+
+	// Re-inject hall of fame members as elites.
+	hof.Clear()
+	hof.Elites = 2
+	pop, _, err = eng.Evolve(10)
+	check(t, err)
+	if pop.Candidates[0] != 13 || pop.Candidates[1] != 11 {
+		t.Errorf("best candidates = %v, want [13 11 ...]", pop.Candidates)
+	}
+}
+
+func TestEngineHallOfFameSurvivingElite(t *testing.T) {
+	// Without Key, candidates are deduplicated by value.
+	hof := &evolve.HallOfFame[int]{Size: 3}
+	eng := Engine[int]{
+		Factory:   zeroFactory,
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			// The seed survives all generations as an elite, while all other
+			// candidates are zeroes.
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+			Elites:    1,
+		},
+		Seeds: []int{7},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](5),
+		},
+		HallOfFame: hof,
+		RNG:        rand.New(rand.NewSource(99)),
+	}
+
+	_, _, err := eng.Evolve(10)
+	check(t, err)
+
+	if got := hof.Population().Candidates; len(got) != 2 || got[0] != 7 || got[1] != 0 {
+		t.Errorf("hall of fame = %v, want [7 0]", got)
+	}
+}
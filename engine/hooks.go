@@ -0,0 +1,61 @@
+package engine
+
+import "github.com/arl/evolve"
+
+// hooks notifies observers of lifecycle events, calling the hooks they
+// implement.
+type hooks[T any] []Observer[T]
+
+// hookSetter is implemented by Epochers that notify lifecycle events
+// happening during an epoch, such as selection or breeding.
+type hookSetter[T any] interface {
+	setHooks(h hooks[T])
+}
+
+func (h hooks[T]) start(eng *Engine[T], popsize int) {
+	for _, o := range h {
+		if so, ok := o.(StartObserver[T]); ok {
+			so.OnStart(eng, popsize)
+		}
+	}
+}
+
+func (h hooks[T]) generationStart(gen int) {
+	for _, o := range h {
+		if gso, ok := o.(GenerationStartObserver[T]); ok {
+			gso.OnGenerationStart(gen)
+		}
+	}
+}
+
+func (h hooks[T]) evaluated(pop *evolve.Population[T]) {
+	for _, o := range h {
+		if eo, ok := o.(EvaluationObserver[T]); ok {
+			eo.OnEvaluated(pop)
+		}
+	}
+}
+
+func (h hooks[T]) selected(parents []T) {
+	for _, o := range h {
+		if so, ok := o.(SelectionObserver[T]); ok {
+			so.OnSelected(parents)
+		}
+	}
+}
+
+func (h hooks[T]) offspring(children []T) {
+	for _, o := range h {
+		if oo, ok := o.(OffspringObserver[T]); ok {
+			oo.OnOffspring(children)
+		}
+	}
+}
+
+func (h hooks[T]) end(res *Result[T]) {
+	for _, o := range h {
+		if eo, ok := o.(EndObserver[T]); ok {
+			eo.OnEnd(res)
+		}
+	}
+}
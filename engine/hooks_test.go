@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+	"github.com/arl/evolve/selection"
+)
+
+// lifecycleRecorder records all the lifecycle events it gets notified of.
+type lifecycleRecorder struct {
+	events []string
+}
+
+func (r *lifecycleRecorder) add(format string, args ...any) {
+	r.events = append(r.events, fmt.Sprintf(format, args...))
+}
+
+func (r *lifecycleRecorder) OnStart(eng *Engine[int], popsize int) { r.add("start(%d)", popsize) }
+func (r *lifecycleRecorder) OnGenerationStart(gen int)             { r.add("gen(%d)", gen) }
+func (r *lifecycleRecorder) OnEvaluated(pop *evolve.Population[int]) {
+	r.add("evaluated(%d)", pop.Len())
+}
+func (r *lifecycleRecorder) OnSelected(parents []int)   { r.add("selected(%d)", len(parents)) }
+func (r *lifecycleRecorder) OnOffspring(children []int) { r.add("offspring(%d)", len(children)) }
+func (r *lifecycleRecorder) OnRestart(stats *evolve.PopulationStats[int]) {
+	r.add("restart(%d)", stats.Generation)
+}
+func (r *lifecycleRecorder) Observe(stats *evolve.PopulationStats[int]) {
+	r.add("observe(%d)", stats.Generation)
+}
+func (r *lifecycleRecorder) OnEnd(res *Result[int]) { r.add("end(%d)", res.Generations) }
+
+func TestEngineLifecycleHooks(t *testing.T) {
+	rec := &lifecycleRecorder{}
+	eng := Engine[int]{
+		Factory: evolve.FactoryFunc[int](func(rng *rand.Rand) int {
+			return rng.Intn(100)
+		}),
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+			Elites:    2,
+		},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](3),
+		},
+		RestartConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](1),
+		},
+		Observers: []Observer[int]{rec},
+		Seed:      1,
+	}
+
+	_, err := eng.Run(10)
+	check(t, err)
+
+	// GenerationCount(1) is always satisfied, so the evolution restarts at
+	// each generation, until it ends.
+	want := []string{
+		"start(10)",
+		"gen(0)", "evaluated(10)", "observe(0)", "restart(0)",
+		"gen(1)", "evaluated(10)", "observe(1)", "restart(1)",
+		"gen(2)", "evaluated(10)", "observe(2)",
+		"end(3)",
+	}
+	if got := strings.Join(rec.events, " "); got != strings.Join(want, " ") {
+		t.Errorf("got events:\n%s\nwant:\n%s", got, strings.Join(want, " "))
+	}
+
+	// Without restarts, the Generational epocher notifies selection and
+	// breeding.
+	rec.events = nil
+	eng.RestartConditions = nil
+	_, err = eng.Run(10)
+	check(t, err)
+
+	want = []string{
+		"start(10)",
+		"gen(0)", "evaluated(10)", "observe(0)",
+		"gen(1)", "selected(8)", "offspring(8)", "evaluated(10)", "observe(1)",
+		"gen(2)", "selected(8)", "offspring(8)", "evaluated(10)", "observe(2)",
+		"end(3)",
+	}
+	if got := strings.Join(rec.events, " "); got != strings.Join(want, " ") {
+		t.Errorf("got events:\n%s\nwant:\n%s", got, strings.Join(want, " "))
+	}
+}
@@ -0,0 +1,231 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+
+	"github.com/arl/evolve"
+)
+
+// MapElites implements the MAP-Elites quality-diversity algorithm.
+//
+// Rather than evolving a population toward a single best solution, MAP-Elites
+// fills a grid archive with a repertoire of diverse solutions. Each cell of the
+// grid corresponds to a combination of discretized behavior descriptors, and
+// holds the fittest candidate found so far exhibiting that behavior.
+//
+// At each epoch, elites are picked at random from the archive and the operator
+// is applied to them. The offspring are evaluated and placed in the cell
+// matching their behavior, if it's empty or if they're fitter than its current
+// occupant.
+//
+// The population returned by Epoch is made of all the elites of the archive,
+// so the population size given to the engine is the size of the initial
+// population only.
+type MapElites[T any] struct {
+	Operator  evolve.Operator[T]
+	Evaluator evolve.Evaluator[T]
+
+	// Behavior computes the behavior descriptors of a candidate, that is one
+	// value per feature.
+	Behavior func(T) []float64
+
+	// Features describes the dimensions of the archive grid, one per behavior
+	// descriptor.
+	Features []Feature
+
+	// BatchSize is the number of offspring generated at each epoch. If 0,
+	// it's set to the size of the initial population.
+	BatchSize int
+
+	// Number of concurrent processes to use (defaults to the number of cores).
+	Concurrency int
+
+	init    bool
+	batch   int
+	archive map[int]*Elite[T]
+	ncells  int
+	evals   int
+}
+
+// A Feature describes a dimension of the MAP-Elites archive grid. Behavior
+// descriptor values in [Min, Max] are discretized into Bins cells of equal
+// size. Values outside of that range fall into the first or last cell.
+type Feature struct {
+	Min, Max float64
+	Bins     int
+}
+
+// An Elite is a candidate held in a cell of the MAP-Elites archive.
+type Elite[T any] struct {
+	// Cell holds the coordinates of the cell in the archive grid.
+	Cell []int
+
+	// Behavior holds the behavior descriptors of the candidate.
+	Behavior []float64
+
+	Candidate T
+	Fitness   float64
+}
+
+// Epoch performs a single step/iteration of the evolutionary process.
+//
+// pop is the population to evolve, sorted by fitness, the fittest first.
+//
+// Returns all the elites of the archive, after the evolutionary process has
+// proceeded by one step/iteration.
+func (e *MapElites[T]) Epoch(pop *evolve.Population[T], rng *rand.Rand) *evolve.Population[T] {
+	if !e.init {
+		if e.Concurrency == 0 {
+			e.Concurrency = runtime.NumCPU()
+		}
+		e.batch = e.BatchSize
+		if e.batch == 0 {
+			e.batch = pop.Len()
+		}
+		e.ncells = 1
+		for i, f := range e.Features {
+			if !(f.Min < f.Max) || math.IsInf(f.Min, 0) || math.IsInf(f.Max, 0) {
+				panic(fmt.Sprintf("MapElites: feature %d must have finite Min < Max", i))
+			}
+			if f.Bins <= 0 {
+				panic(fmt.Sprintf("MapElites: feature %d must have a positive number of bins", i))
+			}
+			e.ncells *= f.Bins
+		}
+		e.archive = make(map[int]*Elite[T])
+
+		// Fill the archive with the initial population, which has been
+		// evaluated by the engine.
+		for i := range pop.Candidates {
+			e.insert(pop.Candidates[i], pop.Fitness[i])
+		}
+		e.init = true
+	}
+
+	e.evals = 0
+	elites := e.sorted()
+	if len(elites) == 0 {
+		return pop
+	}
+
+	parents := make([]T, e.batch)
+	for i := range parents {
+		parents[i] = elites[rng.Intn(len(elites))].Candidate
+	}
+	children := e.Operator.Apply(parents, rng)
+	evchildren := evolve.EvaluatePopulation(children, e.Evaluator, e.Concurrency)
+	e.evals = evchildren.Len()
+	for i := range evchildren.Candidates {
+		e.insert(evchildren.Candidates[i], evchildren.Fitness[i])
+	}
+
+	elites = e.sorted()
+	next := evolve.NewPopulation[T](len(elites))
+	for i, el := range elites {
+		next.Candidates[i] = el.Candidate
+		next.Fitness[i] = el.Fitness
+	}
+	return next
+}
+
+// Reset empties the archive. It's called by the engine on restart, so that the
+// archive gets filled with the restarted population.
+func (e *MapElites[T]) Reset() {
+	e.init = false
+	e.archive = nil
+}
+
+// Evaluations returns the number of candidates evaluated during the last call
+// to Epoch, since it doesn't match the size of the returned population.
+func (e *MapElites[T]) Evaluations() int { return e.evals }
+
+// AnnotateStats reports the archive coverage and QD-score.
+//
+// The QD-score is the sum of the fitness of all the elites, it's only
+// meaningful for natural fitness scores.
+func (e *MapElites[T]) AnnotateStats(stats *evolve.PopulationStats[T]) {
+	if e.ncells == 0 {
+		return
+	}
+	var score float64
+	for _, el := range e.sorted() {
+		score += el.Fitness
+	}
+	stats.Coverage = float64(len(e.archive)) / float64(e.ncells)
+	stats.QDScore = score
+}
+
+// Archive returns a copy of all the elites currently held in the archive,
+// ordered by cell.
+func (e *MapElites[T]) Archive() []Elite[T] {
+	elites := e.sorted()
+	archive := make([]Elite[T], len(elites))
+	for i, el := range elites {
+		archive[i] = *el
+		archive[i].Cell = append([]int(nil), el.Cell...)
+		archive[i].Behavior = append([]float64(nil), el.Behavior...)
+	}
+	return archive
+}
+
+// insert places cand in the archive cell matching its behavior, if the cell is
+// empty or if cand is fitter than the cell occupant.
+func (e *MapElites[T]) insert(cand T, fitness float64) {
+	behavior := e.Behavior(cand)
+	if len(behavior) != len(e.Features) {
+		panic("MapElites: number of behavior descriptors and features differ")
+	}
+
+	cell := make([]int, len(e.Features))
+	key := 0
+	for i, f := range e.Features {
+		var bin int
+		switch v := behavior[i]; {
+		case math.IsNaN(v):
+			panic(fmt.Sprintf("MapElites: behavior descriptor %d is NaN", i))
+		case v <= f.Min:
+			bin = 0
+		case v >= f.Max:
+			bin = f.Bins - 1
+		default:
+			bin = int(math.Floor((v - f.Min) / (f.Max - f.Min) * float64(f.Bins)))
+			if bin >= f.Bins {
+				bin = f.Bins - 1
+			}
+		}
+		cell[i] = bin
+		key = key*f.Bins + bin
+	}
+
+	if cur, ok := e.archive[key]; ok {
+		natural := e.Evaluator.IsNatural()
+		if natural && fitness <= cur.Fitness || !natural && fitness >= cur.Fitness {
+			return
+		}
+	}
+	e.archive[key] = &Elite[T]{
+		Cell:      cell,
+		Behavior:  behavior,
+		Candidate: cand,
+		Fitness:   fitness,
+	}
+}
+
+// sorted returns the elites of the archive, ordered by cell key.
+func (e *MapElites[T]) sorted() []*Elite[T] {
+	keys := make([]int, 0, len(e.archive))
+	for k := range e.archive {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+
+	elites := make([]*Elite[T], len(keys))
+	for i, k := range keys {
+		elites[i] = e.archive[k]
+	}
+	return elites
+}
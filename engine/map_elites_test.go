@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+	"github.com/arl/evolve/generator"
+)
+
+func TestMapElites(t *testing.T) {
+	me := &MapElites[float64]{
+		Operator:  gaussianStep(5),
+		Evaluator: bimodalEvaluator,
+		Behavior:  func(x float64) []float64 { return []float64{x} },
+		Features:  []Feature{{Min: 0, Max: 100, Bins: 10}},
+		BatchSize: 20,
+	}
+
+	var coverage, qdscore []float64
+	obs := ObserverFunc(func(stats *evolve.PopulationStats[float64]) {
+		coverage = append(coverage, stats.Coverage)
+		qdscore = append(qdscore, stats.QDScore)
+	})
+
+	eng := Engine[float64]{
+		// All initial candidates fall into the same cell.
+		Factory: evolve.FactoryFunc[float64](func(rng *rand.Rand) float64 {
+			return 50 + rng.Float64()
+		}),
+		Evaluator: bimodalEvaluator,
+		Epocher:   me,
+		EndConditions: []evolve.Condition[float64]{
+			condition.GenerationCount[float64](50),
+		},
+		Observers: []Observer[float64]{obs},
+		RNG:       rand.New(rand.NewSource(99)),
+	}
+
+	pop, _, err := eng.Evolve(5)
+	check(t, err)
+
+	// Archive should be full after 50 generations.
+	if got := coverage[len(coverage)-1]; got != 1 {
+		t.Errorf("coverage = %v, want 1", got)
+	}
+	if pop.Len() != 10 {
+		t.Errorf("population size = %d, want 10 (1 per cell)", pop.Len())
+	}
+
+	// QD-score can only increase since elites are only replaced by fitter
+	// candidates, and filled cells never get emptied.
+	for i := 2; i < len(qdscore); i++ {
+		if qdscore[i] < qdscore[i-1] {
+			t.Fatalf("QD-score decreased at generation %d: %v -> %v", i, qdscore[i-1], qdscore[i])
+		}
+	}
+
+	archive := me.Archive()
+	if len(archive) != 10 {
+		t.Fatalf("len(archive) = %d, want 10", len(archive))
+	}
+	for i, el := range archive {
+		if el.Cell[0] != i {
+			t.Errorf("archive[%d].Cell = %v, want [%d]", i, el.Cell, i)
+		}
+		if lo, hi := float64(i*10), float64(i*10+10); el.Candidate < lo || el.Candidate > hi {
+			t.Errorf("archive[%d].Candidate = %v, want in [%v, %v]", i, el.Candidate, lo, hi)
+		}
+	}
+}
+
+func TestMapElitesRestart(t *testing.T) {
+	me := &MapElites[float64]{
+		// Candidates never change, so evolution stagnates.
+		Operator:  gaussianStep(0),
+		Evaluator: bimodalEvaluator,
+		Behavior:  func(x float64) []float64 { return []float64{x} },
+		Features:  []Feature{{Min: 0, Max: 100, Bins: 10}},
+	}
+
+	// Initial candidates fall into the first cell, restarted ones into the
+	// last.
+	offset := 5.0
+	onRestart := restartFunc(func() { offset = 95 })
+
+	eng := Engine[float64]{
+		Factory: evolve.FactoryFunc[float64](func(rng *rand.Rand) float64 {
+			return offset + rng.Float64()
+		}),
+		Evaluator: bimodalEvaluator,
+		Epocher:   me,
+		EndConditions: []evolve.Condition[float64]{
+			condition.GenerationCount[float64](5),
+		},
+		RestartConditions: []evolve.Condition[float64]{
+			&condition.Stagnation[float64]{Generations: 2},
+		},
+		Observers: []Observer[float64]{onRestart},
+		RNG:       rand.New(rand.NewSource(99)),
+	}
+
+	_, _, err := eng.Evolve(5)
+	check(t, err)
+
+	// After the restart, the archive only holds the restarted population.
+	archive := me.Archive()
+	if len(archive) != 1 || archive[0].Cell[0] != 9 {
+		t.Errorf("archive = %+v, want a single elite in the last cell", archive)
+	}
+}
+
+// restartFunc is a RestartObserver calling a function on restart.
+type restartFunc func()
+
+func (f restartFunc) Observe(*evolve.PopulationStats[float64])   {}
+func (f restartFunc) OnRestart(*evolve.PopulationStats[float64]) { f() }
+
+func TestMapElitesEvaluations(t *testing.T) {
+	clock := &generator.Clock{Unit: generator.Evaluations}
+	var evals, ticks []int
+	eng := Engine[float64]{
+		// All initial candidates fall into the same cell, so the archive,
+		// returned by Epoch, is much smaller than the batch.
+		Factory: evolve.FactoryFunc[float64](func(rng *rand.Rand) float64 {
+			return 50 + rng.Float64()
+		}),
+		Evaluator: bimodalEvaluator,
+		Epocher: &MapElites[float64]{
+			Operator:  gaussianStep(1),
+			Evaluator: bimodalEvaluator,
+			Behavior:  func(x float64) []float64 { return []float64{x} },
+			Features:  []Feature{{Min: 0, Max: 100, Bins: 10}},
+			BatchSize: 7,
+		},
+		EndConditions: []evolve.Condition[float64]{
+			condition.GenerationCount[float64](4),
+		},
+		Observers: []Observer[float64]{ObserverFunc(func(stats *evolve.PopulationStats[float64]) {
+			evals = append(evals, stats.Evaluations)
+			ticks = append(ticks, clock.Now())
+		})},
+		Clock: clock,
+		RNG:   rand.New(rand.NewSource(99)),
+	}
+
+	_, _, err := eng.Evolve(5)
+	check(t, err)
+
+	// 5 initial candidates, then a batch of 7 per generation.
+	if fmt.Sprint(evals) != "[5 12 19 26]" {
+		t.Errorf("got evaluations %v, want [5 12 19 26]", evals)
+	}
+	if fmt.Sprint(ticks) != "[0 5 12 19]" {
+		t.Errorf("got clock ticks %v, want [0 5 12 19]", ticks)
+	}
+}
+
+func TestMapElitesInvalidFeatures(t *testing.T) {
+	tests := []struct {
+		name     string
+		features []Feature
+		behavior float64
+		want     string
+	}{
+		{"empty range", []Feature{{Min: 1, Max: 1, Bins: 10}}, 0, "MapElites: feature 0 must have finite Min < Max"},
+		{"NaN bound", []Feature{{Min: 0, Max: 1, Bins: 1}, {Min: math.NaN(), Max: 1, Bins: 10}}, 0, "MapElites: feature 1 must have finite Min < Max"},
+		{"zero bins", []Feature{{Min: 0, Max: 1}}, 0, "MapElites: feature 0 must have a positive number of bins"},
+		{"NaN descriptor", []Feature{{Min: 0, Max: 1, Bins: 10}}, math.NaN(), "MapElites: behavior descriptor 0 is NaN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			me := &MapElites[float64]{
+				Operator:  gaussianStep(5),
+				Evaluator: bimodalEvaluator,
+				Behavior: func(x float64) []float64 {
+					b := make([]float64, len(tt.features))
+					b[0] = tt.behavior
+					return b
+				},
+				Features: tt.features,
+			}
+			pop := &evolve.Population[float64]{Candidates: []float64{1}, Fitness: []float64{1}}
+			assert.PanicsWithValue(t, tt.want, func() {
+				me.Epoch(pop, rand.New(rand.NewSource(99)))
+			})
+		})
+	}
+}
+
+func TestMapElitesInfiniteDescriptors(t *testing.T) {
+	me := &MapElites[float64]{
+		Operator:  gaussianStep(5),
+		Evaluator: bimodalEvaluator,
+		Behavior:  func(x float64) []float64 { return []float64{math.Inf(int(x))} },
+		Features:  []Feature{{Min: 0, Max: 1, Bins: 10}},
+	}
+	pop := &evolve.Population[float64]{Candidates: []float64{-1, 1}, Fitness: []float64{1, 1}}
+	me.Epoch(pop, rand.New(rand.NewSource(99)))
+
+	// Infinite descriptors fall into the first or last cell.
+	var cells []int
+	for _, el := range me.Archive() {
+		cells = append(cells, el.Cell[0])
+	}
+	if fmt.Sprint(cells) != "[0 9]" {
+		t.Errorf("got cells %v, want [0 9]", cells)
+	}
+}
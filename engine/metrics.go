@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/arl/evolve"
+)
+
+// Metrics collects evolution metrics and exposes them over HTTP, in the
+// OpenMetrics text format, so that they can be scraped by Prometheus or any
+// compatible monitoring system.
+//
+// Metrics are fed by MetricsObserver, one per evolution run or island, each
+// being identified by a distinct set of labels.
+//
+// Metrics is safe for concurrent use.
+type Metrics struct {
+	mu     sync.Mutex
+	series map[metricsLabels]*metricsSeries
+	order  []metricsLabels
+}
+
+type metricsLabels struct {
+	run, island string
+}
+
+type metricsSeries struct {
+	generation    int
+	best          float64
+	mean          float64
+	stddev        float64
+	evaluations   int
+	evalsPerSec   float64
+	elapsed       float64 // seconds
+	cacheHitRatio float64
+	hasCache      bool
+}
+
+// NewMetrics returns a new, empty, Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{series: make(map[metricsLabels]*metricsSeries)}
+}
+
+// ServeHTTP writes the current value of all metrics in the OpenMetrics text
+// format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	m.WriteTo(w)
+}
+
+// WriteTo writes the current value of all metrics to w, in the OpenMetrics
+// text format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var sb strings.Builder
+	family := func(name, typ, help string, value func(*metricsSeries) (float64, bool)) {
+		fmt.Fprintf(&sb, "# TYPE %s %s\n", name, typ)
+		fmt.Fprintf(&sb, "# HELP %s %s\n", name, help)
+		sample := name
+		if typ == "counter" {
+			sample += "_total"
+		}
+		for _, l := range m.order {
+			v, ok := value(m.series[l])
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(&sb, "%s{run=\"%s\",island=\"%s\"} %s\n",
+				sample, escapeLabel(l.run), escapeLabel(l.island), strconv.FormatFloat(v, 'g', -1, 64))
+		}
+	}
+
+	family("evolve_generation", "gauge", "Index of the last processed generation.",
+		func(s *metricsSeries) (float64, bool) { return float64(s.generation), true })
+	family("evolve_best_fitness", "gauge", "Fitness of the best candidate of the last generation.",
+		func(s *metricsSeries) (float64, bool) { return s.best, true })
+	family("evolve_mean_fitness", "gauge", "Mean fitness of the last generation.",
+		func(s *metricsSeries) (float64, bool) { return s.mean, true })
+	family("evolve_fitness_stddev", "gauge", "Standard deviation of the fitness of the last generation.",
+		func(s *metricsSeries) (float64, bool) { return s.stddev, true })
+	family("evolve_evaluations", "counter", "Number of fitness evaluations.",
+		func(s *metricsSeries) (float64, bool) { return float64(s.evaluations), true })
+	family("evolve_evaluations_per_second", "gauge", "Rate of fitness evaluations during the last generation.",
+		func(s *metricsSeries) (float64, bool) { return s.evalsPerSec, true })
+	family("evolve_cache_hit_ratio", "gauge", "Ratio of fitness evaluations served by the fitness cache.",
+		func(s *metricsSeries) (float64, bool) { return s.cacheHitRatio, s.hasCache })
+	family("evolve_elapsed_seconds", "gauge", "Time elapsed since the evolution start.",
+		func(s *metricsSeries) (float64, bool) { return s.elapsed, true })
+	sb.WriteString("# EOF\n")
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// escapeLabel escapes a label value as per the OpenMetrics text format.
+func escapeLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// MetricsObserver is an Observer updating Metrics with the population
+// statistics of an evolution run.
+type MetricsObserver[T any] struct {
+	// Metrics is where metrics are collected.
+	Metrics *Metrics
+
+	// Run and Island are the values of the run and island labels of the
+	// metrics reported by this observer.
+	Run, Island string
+
+	// Cache, if set, is the fitness cache whose hit ratio is reported, for
+	// example an evolve.FitnessCache.
+	Cache interface{ HitRatio() float64 }
+}
+
+// Observe updates the metrics with the population statistics.
+func (o *MetricsObserver[T]) Observe(stats *evolve.PopulationStats[T]) {
+	m := o.Metrics
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	l := metricsLabels{run: o.Run, island: o.Island}
+	s, ok := m.series[l]
+	if !ok {
+		s = &metricsSeries{}
+		m.series[l] = s
+		m.order = append(m.order, l)
+	}
+
+	elapsed := stats.Elapsed.Seconds()
+	if dt := elapsed - s.elapsed; dt > 0 {
+		s.evalsPerSec = float64(stats.Evaluations-s.evaluations) / dt
+	}
+	s.generation = stats.Generation
+	s.best = stats.BestFitness
+	s.mean = stats.Mean
+	s.stddev = stats.StdDev
+	s.evaluations = stats.Evaluations
+	s.elapsed = elapsed
+	if o.Cache != nil {
+		s.cacheHitRatio = o.Cache.HitRatio()
+		s.hasCache = true
+	}
+}
@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arl/evolve"
+)
+
+type constHitRatio float64
+
+func (r constHitRatio) HitRatio() float64 { return float64(r) }
+
+func TestMetrics(t *testing.T) {
+	m := NewMetrics()
+	obs1 := &MetricsObserver[int]{Metrics: m, Run: "run1", Island: "0", Cache: constHitRatio(0.25)}
+	obs2 := &MetricsObserver[int]{Metrics: m, Run: "run1", Island: `"1"`}
+
+	obs1.Observe(&evolve.PopulationStats[int]{Generation: 0, Evaluations: 100, Elapsed: time.Second})
+	obs1.Observe(&evolve.PopulationStats[int]{
+		Generation:  1,
+		BestFitness: 12.5,
+		Mean:        4,
+		StdDev:      1.5,
+		Evaluations: 300,
+		Elapsed:     2 * time.Second,
+	})
+	obs2.Observe(&evolve.PopulationStats[int]{Generation: 7, Evaluations: 20})
+
+	srv := httptest.NewServer(m)
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(srv.URL)
+	check(t, err)
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "application/openmetrics-text") {
+		t.Errorf("Content-Type = %q, want application/openmetrics-text", ct)
+	}
+	buf, err := io.ReadAll(resp.Body)
+	check(t, err)
+	body := string(buf)
+
+	for _, want := range []string{
+		"# TYPE evolve_generation gauge\n",
+		`evolve_generation{run="run1",island="0"} 1` + "\n",
+		`evolve_generation{run="run1",island="\"1\""} 7` + "\n",
+		`evolve_best_fitness{run="run1",island="0"} 12.5` + "\n",
+		`evolve_mean_fitness{run="run1",island="0"} 4` + "\n",
+		`evolve_fitness_stddev{run="run1",island="0"} 1.5` + "\n",
+		"# TYPE evolve_evaluations counter\n",
+		`evolve_evaluations_total{run="run1",island="0"} 300` + "\n",
+		`evolve_evaluations_per_second{run="run1",island="0"} 200` + "\n",
+		`evolve_cache_hit_ratio{run="run1",island="0"} 0.25` + "\n",
+		`evolve_elapsed_seconds{run="run1",island="0"} 2` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `evolve_cache_hit_ratio{run="run1",island="\"1\""}`) {
+		t.Errorf("cache hit ratio reported for an observer without cache:\n%s", body)
+	}
+	if !strings.HasSuffix(body, "# EOF\n") {
+		t.Errorf("exposition should end with # EOF:\n%s", body)
+	}
+}
@@ -13,6 +13,22 @@ type Observer[T any] interface {
 	Observe(*evolve.PopulationStats[T])
 }
 
+// A RestartObserver is an Observer that also gets notified when the engine
+// restarts the evolution.
+//
+// Restarts are notified in order with the population statistics: OnRestart is
+// called after Observe has been called for the generation that triggered the
+// restart, and before it's called for the next one. When ObserverQueue is set,
+// restarts are thus queued, and notified asynchronously, like population
+// statistics. Contrary to them, restart notifications are never dropped.
+type RestartObserver[T any] interface {
+	Observer[T]
+
+	// OnRestart is called when the evolution restarts, with the statistics of
+	// the generation that triggered the restart.
+	OnRestart(*evolve.PopulationStats[T])
+}
+
 type observerFunc[T any] struct {
 	f func(*evolve.PopulationStats[T])
 }
@@ -26,3 +42,78 @@ func ObserverFunc[T any](f func(*evolve.PopulationStats[T])) Observer[T] {
 }
 
 func (obs *observerFunc[T]) Observe(stats *evolve.PopulationStats[T]) { obs.f(stats) }
+
+// The following interfaces define optional lifecycle hooks. An Observer
+// registered within the engine gets notified of the corresponding events if it
+// implements one or more of them. Contrary to Observe and OnRestart, lifecycle
+// hooks are always called synchronously, from the goroutine running the engine,
+// even when ObserverQueue is set. So they must return quickly and not keep
+// references to the populations or candidates they receive, since those may be
+// modified afterwards.
+//
+// When ObserverQueue is set, Observe and OnRestart are called from another
+// goroutine, concurrently with the lifecycle hooks, so an observer implementing
+// both must protect its state from concurrent access. In any case, OnEnd is
+// called once all the queued notifications have been delivered.
+
+// A StartObserver is an Observer that also gets notified when the evolution
+// starts.
+type StartObserver[T any] interface {
+	Observer[T]
+
+	// OnStart is called once, before the initial population is created, with
+	// the engine configuration and the initial population size.
+	OnStart(eng *Engine[T], popsize int)
+}
+
+// A GenerationStartObserver is an Observer that also gets notified when a new
+// generation starts.
+type GenerationStartObserver[T any] interface {
+	Observer[T]
+
+	// OnGenerationStart is called at the start of each generation, before the
+	// population of that generation is bred (or created, for the initial
+	// generation and after a restart).
+	OnGenerationStart(gen int)
+}
+
+// An EvaluationObserver is an Observer that also gets notified once the
+// population of a generation has been evaluated.
+type EvaluationObserver[T any] interface {
+	Observer[T]
+
+	// OnEvaluated is called with the evaluated population, before it's sorted
+	// by fitness.
+	OnEvaluated(pop *evolve.Population[T])
+}
+
+// A SelectionObserver is an Observer that also gets notified of the
+// candidates selected for breeding. It's only notified by Epochers supporting
+// it, such as Generational.
+type SelectionObserver[T any] interface {
+	Observer[T]
+
+	// OnSelected is called with the selected parents, before the evolutionary
+	// operators are applied to them.
+	OnSelected(parents []T)
+}
+
+// An OffspringObserver is an Observer that also gets notified of the
+// offspring bred by the evolutionary operators. It's only notified by Epochers
+// supporting it, such as Generational.
+type OffspringObserver[T any] interface {
+	Observer[T]
+
+	// OnOffspring is called with the offspring, before they're evaluated.
+	OnOffspring(children []T)
+}
+
+// An EndObserver is an Observer that also gets notified when the evolution is
+// over.
+type EndObserver[T any] interface {
+	Observer[T]
+
+	// OnEnd is called once, when one of the termination conditions is met,
+	// with the result of the evolution run.
+	OnEnd(res *Result[T])
+}
@@ -0,0 +1,345 @@
+package engine
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+	"golang.org/x/term"
+)
+
+// Progress is an Observer rendering the progress of an evolution run on a
+// terminal: generation, best and mean fitness sparklines, evaluation rate,
+// estimated time of arrival and the best candidate.
+//
+// When the output is not a terminal, Progress falls back to writing plain log
+// lines, at most once per Interval.
+//
+// The estimated time of arrival is computed from the engine termination
+// conditions, which Progress reads when the evolution starts. Only
+// GenerationCount, ElapsedTime and EvaluationCount conditions are considered.
+// If none of them is present, no ETA is shown.
+//
+// Rendering takes place in a separate goroutine, so that a slow terminal never
+// blocks the engine: if rendering can't keep up, intermediate generations are
+// skipped. The final generation is rendered when Progress is flushed, which
+// the engine does once evolution is over. Close must then be called in order to
+// stop the rendering goroutine.
+type Progress[T any] struct {
+	// Format pretty-prints the best candidate. If nil, the best candidate is
+	// not shown.
+	Format func(T) string
+
+	// TTY indicates whether the output is a terminal, in which case the
+	// display is redrawn in place. It's set by NewProgress.
+	TTY bool
+
+	// Interval is the minimum interval between 2 renderings. If 0, it
+	// defaults to 100ms on a terminal and 1s otherwise.
+	Interval time.Duration
+
+	// Width is the number of generations shown in sparklines. If 0, it
+	// defaults to 40.
+	Width int
+
+	w         io.Writer
+	once      sync.Once
+	closeOnce sync.Once
+	wake      chan struct{}
+	quit      chan struct{}
+	done      chan struct{}
+
+	mu      sync.Mutex
+	conds   []evolve.Condition[T] // engine termination conditions
+	cur     progressState
+	dirty   bool
+	best    []float64
+	mean    []float64
+	prevGen progressState
+
+	outmu  sync.Mutex // serializes renderings
+	nlines int        // number of lines drawn on the terminal
+}
+
+// progressState is a snapshot of the progress of the evolution.
+type progressState struct {
+	generation  int
+	bestFitness float64
+	mean        float64
+	evaluations int
+	evalsPerSec float64
+	elapsed     time.Duration
+	eta         time.Duration // negative if unknown
+	best        string
+}
+
+// NewProgress returns a Progress writing to w. If w is an *os.File connected
+// to a terminal, TTY is set.
+func NewProgress[T any](w io.Writer) *Progress[T] {
+	return &Progress[T]{w: w, TTY: isTerminal(w)}
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+func (p *Progress[T]) start() {
+	p.once.Do(func() {
+		if p.Width == 0 {
+			p.Width = 40
+		}
+		if p.Interval == 0 {
+			p.Interval = time.Second
+			if p.TTY {
+				p.Interval = 100 * time.Millisecond
+			}
+		}
+		p.wake = make(chan struct{}, 1)
+		p.quit = make(chan struct{})
+		p.done = make(chan struct{})
+		go p.loop()
+	})
+}
+
+// OnStart implements StartObserver. It records the engine termination
+// conditions, used to estimate the time of arrival.
+func (p *Progress[T]) OnStart(eng *Engine[T], popsize int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.conds = append([]evolve.Condition[T](nil), eng.EndConditions...)
+}
+
+// Observe records the population statistics, to be rendered by the rendering
+// goroutine. It never blocks on the output.
+func (p *Progress[T]) Observe(stats *evolve.PopulationStats[T]) {
+	p.start()
+
+	st := progressState{
+		generation:  stats.Generation,
+		bestFitness: stats.BestFitness,
+		mean:        stats.Mean,
+		evaluations: stats.Evaluations,
+		elapsed:     stats.Elapsed,
+	}
+	if p.Format != nil {
+		st.best = p.Format(stats.Best)
+	}
+
+	p.mu.Lock()
+	st.eta = eta(stats, p.conds)
+	if dt := stats.Elapsed - p.prevGen.elapsed; dt > 0 {
+		st.evalsPerSec = float64(stats.Evaluations-p.prevGen.evaluations) / dt.Seconds()
+	} else {
+		st.evalsPerSec = p.prevGen.evalsPerSec
+	}
+	p.prevGen = st
+	p.cur = st
+	p.dirty = true
+	p.best = appendWindow(p.best, stats.BestFitness, p.Width)
+	p.mean = appendWindow(p.mean, stats.Mean, p.Width)
+	p.mu.Unlock()
+
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Flush renders the last observed generation, if it hasn't been rendered yet,
+// without waiting for the rendering goroutine.
+func (p *Progress[T]) Flush() error {
+	p.render()
+	return nil
+}
+
+// Close renders the last observed generation and stops the rendering
+// goroutine. Observe must not be called after Close. Subsequent calls to Close
+// do nothing.
+func (p *Progress[T]) Close() error {
+	p.start()
+	p.closeOnce.Do(func() { close(p.quit) })
+	<-p.done
+	return nil
+}
+
+// loop renders the progress each time it's woken up, at most once per Interval,
+// until Close is called.
+func (p *Progress[T]) loop() {
+	defer close(p.done)
+
+	var last time.Time
+	for {
+		closed := false
+		select {
+		case <-p.wake:
+			if wait := p.Interval - time.Since(last); wait > 0 {
+				select {
+				case <-time.After(wait):
+				case <-p.quit:
+					closed = true
+				}
+			}
+		case <-p.quit:
+			closed = true
+		}
+
+		if p.render() {
+			last = time.Now()
+		}
+		if closed {
+			return
+		}
+	}
+}
+
+// render renders the last observed generation if it hasn't been rendered yet,
+// and reports whether it did.
+func (p *Progress[T]) render() bool {
+	p.outmu.Lock()
+	defer p.outmu.Unlock()
+
+	p.mu.Lock()
+	st, dirty := p.cur, p.dirty
+	best := append([]float64(nil), p.best...)
+	mean := append([]float64(nil), p.mean...)
+	p.dirty = false
+	p.mu.Unlock()
+
+	if !dirty {
+		return false
+	}
+	if p.TTY {
+		p.draw(st, best, mean)
+	} else {
+		p.logLine(st)
+	}
+	return true
+}
+
+// draw redraws the progress on the terminal, in place.
+func (p *Progress[T]) draw(st progressState, best, mean []float64) {
+	var sb strings.Builder
+	if p.nlines > 0 {
+		// Move the cursor up to the first line and clear the previous display.
+		fmt.Fprintf(&sb, "\x1b[%dA\x1b[J", p.nlines)
+	}
+
+	lines := []string{
+		fmt.Sprintf("generation %d   evaluations %d (%.0f/s)   elapsed %v   ETA %s",
+			st.generation, st.evaluations, st.evalsPerSec, st.elapsed.Round(time.Millisecond), formatETA(st.eta)),
+		fmt.Sprintf("best %-12g %s", st.bestFitness, sparkline(best)),
+		fmt.Sprintf("mean %-12g %s", st.mean, sparkline(mean)),
+	}
+	if st.best != "" {
+		lines = append(lines, "best candidate:")
+		lines = append(lines, strings.Split(strings.TrimRight(st.best, "\n"), "\n")...)
+	}
+	for _, l := range lines {
+		sb.WriteString(l)
+		sb.WriteByte('\n')
+	}
+	p.nlines = len(lines)
+	io.WriteString(p.w, sb.String())
+}
+
+// logLine writes the progress as a plain log line.
+func (p *Progress[T]) logLine(st progressState) {
+	line := fmt.Sprintf("generation=%d best=%g mean=%g evaluations=%d evals/s=%.0f elapsed=%v eta=%s",
+		st.generation, st.bestFitness, st.mean, st.evaluations, st.evalsPerSec, st.elapsed.Round(time.Millisecond), formatETA(st.eta))
+	if st.best != "" {
+		line += " best_candidate=" + fmt.Sprintf("%q", st.best)
+	}
+	io.WriteString(p.w, line+"\n")
+}
+
+// eta estimates the remaining time before one of the termination conditions
+// is met, or returns -1 if it can't be estimated.
+func eta[T any](stats *evolve.PopulationStats[T], conds []evolve.Condition[T]) time.Duration {
+	est := time.Duration(-1)
+	update := func(d time.Duration) {
+		if d < 0 {
+			d = 0
+		}
+		if est < 0 || d < est {
+			est = d
+		}
+	}
+
+	for _, cond := range conds {
+		switch c := cond.(type) {
+		case condition.GenerationCount[T]:
+			remaining := int(c) - (stats.Generation + 1)
+			perGen := stats.Elapsed / time.Duration(stats.Generation+1)
+			update(time.Duration(remaining) * perGen)
+		case condition.ElapsedTime[T]:
+			update(time.Duration(c) - stats.Elapsed)
+		case condition.EvaluationCount[T]:
+			if stats.Evaluations == 0 {
+				continue
+			}
+			remaining := float64(int(c) - stats.Evaluations)
+			update(time.Duration(remaining / float64(stats.Evaluations) * float64(stats.Elapsed)))
+		}
+	}
+	return est
+}
+
+func formatETA(d time.Duration) string {
+	if d < 0 {
+		return "?"
+	}
+	return d.Round(time.Second).String()
+}
+
+// appendWindow appends v to vals, keeping at most the last n values.
+func appendWindow(vals []float64, v float64, n int) []float64 {
+	vals = append(vals, v)
+	if len(vals) > n {
+		vals = append(vals[:0], vals[len(vals)-n:]...)
+	}
+	return vals
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline returns a sparkline representing vals, scaled between their finite
+// minimum and maximum. Non-finite values are shown as blanks.
+func sparkline(vals []float64) string {
+	finite := func(v float64) bool { return !math.IsNaN(v) && !math.IsInf(v, 0) }
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range vals {
+		if finite(v) {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+
+	rs := make([]rune, len(vals))
+	for i, v := range vals {
+		if !finite(v) {
+			rs[i] = ' '
+			continue
+		}
+		idx := 0
+		if max > min {
+			// Halve values so that differences can't overflow.
+			idx = int((v/2 - min/2) / (max/2 - min/2) * float64(len(sparks)-1))
+		}
+		if idx < 0 {
+			idx = 0
+		} else if idx >= len(sparks) {
+			idx = len(sparks) - 1
+		}
+		rs[i] = sparks[idx]
+	}
+	return string(rs)
+}
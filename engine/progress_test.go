@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+)
+
+func TestProgressETA(t *testing.T) {
+	stats := &evolve.PopulationStats[int]{
+		Generation:  9,
+		Elapsed:     10 * time.Second,
+		Evaluations: 1000,
+	}
+
+	tests := []struct {
+		name  string
+		conds []evolve.Condition[int]
+		want  time.Duration
+	}{
+		{"none", nil, -1},
+		{"unsupported", []evolve.Condition[int]{condition.TargetFitness[int]{Fitness: 1}}, -1},
+		{"generations", []evolve.Condition[int]{condition.GenerationCount[int](30)}, 20 * time.Second},
+		{"elapsed", []evolve.Condition[int]{condition.ElapsedTime[int](15 * time.Second)}, 5 * time.Second},
+		{"evaluations", []evolve.Condition[int]{condition.EvaluationCount[int](4000)}, 30 * time.Second},
+		{"elapsed exceeded", []evolve.Condition[int]{condition.ElapsedTime[int](time.Second)}, 0},
+		{
+			"earliest",
+			[]evolve.Condition[int]{
+				condition.GenerationCount[int](30),
+				condition.ElapsedTime[int](15 * time.Second),
+				condition.EvaluationCount[int](4000),
+			},
+			5 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := eta(stats, tt.conds); got != tt.want {
+				t.Errorf("eta = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProgressSparkline(t *testing.T) {
+	if got := sparkline([]float64{0, 1, 2, 3, 4, 5, 6, 7}); got != "▁▂▃▄▅▆▇█" {
+		t.Errorf("sparkline = %q", got)
+	}
+	if got := sparkline([]float64{3, 3, 3}); got != "▁▁▁" {
+		t.Errorf("sparkline of constant values = %q", got)
+	}
+	if got := sparkline([]float64{0, math.Inf(1), 7, math.NaN(), math.Inf(-1)}); got != "▁ █  " {
+		t.Errorf("sparkline of non-finite values = %q", got)
+	}
+	if got := sparkline([]float64{math.Inf(1), math.Inf(1)}); got != "  " {
+		t.Errorf("sparkline of infinite values = %q", got)
+	}
+	// 1e308 - (-1e308) overflows to +Inf.
+	if got := sparkline([]float64{-1e308, 0, 1e308}); got != "▁▄█" {
+		t.Errorf("sparkline of huge values = %q", got)
+	}
+}
+
+func TestProgressEngineConditions(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgress[string](&buf)
+
+	eng := &Engine[string]{
+		EndConditions: []evolve.Condition[string]{condition.GenerationCount[string](10)},
+	}
+	var _ StartObserver[string] = p
+	p.OnStart(eng, 10)
+	p.Observe(&evolve.PopulationStats[string]{
+		Generation: 4,
+		Elapsed:    5 * time.Second,
+	})
+	p.Close()
+
+	// 5 generations remaining, at 1s per generation.
+	if out := buf.String(); !strings.Contains(out, "eta=5s") {
+		t.Errorf("ETA not computed from engine conditions, got:\n%s", out)
+	}
+}
+
+func TestProgressPlain(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgress[string](&buf)
+	if p.TTY {
+		t.Fatal("a buffer is not a terminal")
+	}
+	p.Format = func(s string) string { return s }
+	p.Interval = time.Hour
+
+	for i := 0; i < 5; i++ {
+		p.Observe(&evolve.PopulationStats[string]{
+			Best:        "best",
+			BestFitness: float64(i),
+			Generation:  i,
+			Evaluations: 10 * (i + 1),
+			Elapsed:     time.Duration(i+1) * time.Second,
+		})
+	}
+	p.Close()
+
+	// With an interval of an hour, the final generation must still be logged.
+	out := buf.String()
+	if !strings.Contains(out, "generation=4 best=4 ") {
+		t.Errorf("final generation not logged, got:\n%s", out)
+	}
+	if !strings.Contains(out, `best_candidate="best"`) {
+		t.Errorf("best candidate not logged, got:\n%s", out)
+	}
+	if n := strings.Count(out, "\n"); n > 2 {
+		t.Errorf("got %d log lines, want at most 2", n)
+	}
+}
+
+func TestProgressTTY(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgress[string](&buf)
+	p.TTY = true
+	p.Format = func(s string) string { return "line1\nline2" }
+	p.Interval = time.Millisecond
+
+	p.Observe(&evolve.PopulationStats[string]{Generation: 0, Elapsed: time.Second})
+	time.Sleep(20 * time.Millisecond)
+	p.Observe(&evolve.PopulationStats[string]{Generation: 1, Elapsed: 2 * time.Second})
+	p.Close()
+
+	out := buf.String()
+	if !strings.Contains(out, "generation 1 ") {
+		t.Errorf("final generation not drawn, got:\n%s", out)
+	}
+	if !strings.Contains(out, "best candidate:\nline1\nline2\n") {
+		t.Errorf("best candidate not drawn, got:\n%s", out)
+	}
+	// The second drawing must erase the 6 lines of the first one.
+	if !strings.Contains(out, "\x1b[6A\x1b[J") {
+		t.Errorf("display not redrawn in place, got:\n%q", out)
+	}
+}
+
+func TestProgressFlush(t *testing.T) {
+	var buf bytes.Buffer
+	p := NewProgress[string](&buf)
+	p.Interval = time.Hour
+
+	var _ flusher = p
+	p.Observe(&evolve.PopulationStats[string]{Generation: 0, Elapsed: time.Second})
+	p.Observe(&evolve.PopulationStats[string]{Generation: 1, Elapsed: 2 * time.Second})
+	check(t, p.Flush())
+
+	// The last generation is rendered by Flush, before Close.
+	if out := buf.String(); !strings.Contains(out, "generation=1 ") {
+		t.Errorf("last generation not rendered by Flush, got:\n%s", out)
+	}
+	check(t, p.Close())
+	check(t, p.Close())
+	if n := strings.Count(buf.String(), "generation=1 "); n != 1 {
+		t.Errorf("last generation rendered %d times, want 1", n)
+	}
+}
+
+func TestProgressNotTerminal(t *testing.T) {
+	f, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	check(t, err)
+	defer f.Close()
+
+	if NewProgress[string](f).TTY {
+		t.Errorf("%s is not a terminal", os.DevNull)
+	}
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"math"
+
+	"github.com/arl/evolve"
+)
+
+// resetter is implemented by conditions and epochers that hold some state that
+// can be reset, such as condition.Stagnation or MapElites.
+type resetter interface {
+	Reset()
+}
+
+// restartSize returns the population size to use after a restart, as well as
+// the updated largest population size.
+func (e *Engine[T]) restartSize(initsize, largest int) (size, newlargest int) {
+	if e.RestartBIPOP && e.restarts%2 == 1 {
+		// Small population regime.
+		u := e.RNG.Float64()
+		ratio := 0.5 * float64(largest) / float64(initsize)
+		size = int(math.Floor(float64(initsize) * math.Pow(ratio, u*u)))
+		if size < initsize {
+			size = initsize
+		}
+		return size, largest
+	}
+
+	// Large population regime.
+	if e.RestartGrowth > 1 {
+		largest = int(math.Ceil(float64(largest) * e.RestartGrowth))
+	}
+	return largest, largest
+}
+
+// notifyRestart resets the restart conditions and the epocher, and notifies
+// the observers implementing RestartObserver.
+func (e *Engine[T]) notifyRestart(stats *evolve.PopulationStats[T]) {
+	for _, c := range e.RestartConditions {
+		if r, ok := c.(resetter); ok {
+			r.Reset()
+		}
+	}
+	if r, ok := e.Epocher.(resetter); ok {
+		r.Reset()
+	}
+	for _, o := range e.observers {
+		if ro, ok := o.(RestartObserver[T]); ok {
+			ro.OnRestart(stats)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package engine
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+	"github.com/arl/evolve/selection"
+)
+
+type restartRecorder struct {
+	sizes, restarts []int
+	best            []float64
+	onRestart       int
+}
+
+func (r *restartRecorder) Observe(stats *evolve.PopulationStats[int]) {
+	r.sizes = append(r.sizes, stats.Size)
+	r.restarts = append(r.restarts, stats.Restarts)
+	r.best = append(r.best, stats.BestFitness)
+}
+
+func (r *restartRecorder) OnRestart(stats *evolve.PopulationStats[int]) { r.onRestart++ }
+
+func TestEngineRestart(t *testing.T) {
+	rec := &restartRecorder{}
+	eng := Engine[int]{
+		Factory: evolve.FactoryFunc[int](func(rng *rand.Rand) int {
+			return rng.Intn(100)
+		}),
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			// Evolution quickly stagnates, all candidates being 0.
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](10),
+		},
+		RestartConditions: []evolve.Condition[int]{
+			&condition.Stagnation[int]{Generations: 2},
+		},
+		RestartElites: 1,
+		RestartGrowth: 2,
+		Observers:     []Observer[int]{rec},
+		RNG:           rand.New(rand.NewSource(99)),
+	}
+
+	_, _, err := eng.Evolve(10)
+	check(t, err)
+
+	// Generation 0: random population, best is kept in the hall of fame.
+	// Generations 1 and 2: only zeroes, restart is triggered at generation 2.
+	// Generation 3: restarted population, and so on.
+	wantSizes := []int{10, 10, 10, 20, 20, 20, 40, 40, 40, 80}
+	wantRestarts := []int{0, 0, 0, 1, 1, 1, 2, 2, 2, 3}
+	for i := range wantSizes {
+		if rec.sizes[i] != wantSizes[i] || rec.restarts[i] != wantRestarts[i] {
+			t.Fatalf("generation %d: size=%d restarts=%d, want size=%d restarts=%d",
+				i, rec.sizes[i], rec.restarts[i], wantSizes[i], wantRestarts[i])
+		}
+	}
+	if rec.onRestart != 3 {
+		t.Errorf("OnRestart called %d times, want 3", rec.onRestart)
+	}
+
+	// Restarted populations are seeded with the best candidate found so far.
+	for i := 3; i < len(rec.best); i += 3 {
+		if rec.best[i] < rec.best[0] {
+			t.Errorf("generation %d: best fitness = %v, want >= %v", i, rec.best[i], rec.best[0])
+		}
+	}
+}
+
+func TestEngineRestartBIPOP(t *testing.T) {
+	rec := &restartRecorder{}
+	eng := Engine[int]{
+		Factory:   zeroFactory,
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](9),
+		},
+		RestartConditions: []evolve.Condition[int]{
+			condition.Converged[int](0),
+		},
+		RestartGrowth: 4,
+		RestartBIPOP:  true,
+		Observers:     []Observer[int]{rec},
+		RNG:           rand.New(rand.NewSource(99)),
+	}
+
+	_, _, err := eng.Evolve(10)
+	check(t, err)
+
+	// Restart at each generation, alternating between large and small
+	// population regimes.
+	largest := 10
+	for i := 1; i < len(rec.sizes); i++ {
+		if i%2 == 1 {
+			largest *= 4
+			if rec.sizes[i] != largest {
+				t.Errorf("generation %d: size = %d, want %d (large regime)", i, rec.sizes[i], largest)
+			}
+		} else if rec.sizes[i] < 10 || rec.sizes[i] > largest/2 {
+			t.Errorf("generation %d: size = %d, want in [10, %d] (small regime)", i, rec.sizes[i], largest/2)
+		}
+	}
+}
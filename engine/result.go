@@ -0,0 +1,155 @@
+package engine
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/arl/evolve"
+)
+
+// Result describes a complete evolution run, as returned by Engine.Run.
+type Result[T any] struct {
+	// Population is the entire population of the final generation, sorted by
+	// fitness, the fittest first.
+	Population *evolve.Population[T]
+
+	// Best is the fittest candidate seen during the whole evolution, and
+	// BestFitness is its fitness.
+	Best        T
+	BestFitness float64
+
+	// BestGeneration is the generation at which Best has been found.
+	BestGeneration int
+
+	// Generations is the number of generations that have been processed.
+	Generations int
+
+	// Evaluations is the total number of fitness evaluations.
+	Evaluations int
+
+	// Elapsed is the wall time duration of the evolution.
+	Elapsed time.Duration
+
+	// Satisfied holds the termination conditions that were satisfied at the
+	// end of the evolution.
+	Satisfied []evolve.Condition[T]
+
+	// History holds the population statistics of the generations recorded
+	// during the evolution, as controlled by Engine.HistoryInterval.
+	History []evolve.PopulationStats[T]
+
+	// Seed is the seed of the engine RNG. It's only meaningful if the RNG has
+	// been created by the engine, see Engine.Seed.
+	Seed int64
+}
+
+// MarshalJSON implements json.Marshaler. Since conditions can't be serialized,
+// satisfied conditions are encoded by their string representation. Since JSON
+// numbers can't represent them, NaN and infinite values are encoded as the
+// strings "NaN", "+Inf" and "-Inf".
+func (r *Result[T]) MarshalJSON() ([]byte, error) {
+	type result Result[T] // prevent recursion
+	satisfied := make([]string, len(r.Satisfied))
+	for i, c := range r.Satisfied {
+		satisfied[i] = c.String()
+	}
+	history := make([]jsonStats[T], len(r.History))
+	for i := range r.History {
+		history[i] = newJSONStats(&r.History[i])
+	}
+	if r.History == nil {
+		history = nil
+	}
+	return json.Marshal(struct {
+		*result
+		Population  *jsonPopulation[T]
+		BestFitness jsonFloat
+		Satisfied   []string
+		History     []jsonStats[T]
+	}{
+		result:      (*result)(r),
+		Population:  newJSONPopulation(r.Population),
+		BestFitness: jsonFloat(r.BestFitness),
+		Satisfied:   satisfied,
+		History:     history,
+	})
+}
+
+// A jsonFloat is a float64 encoded as a string in JSON when it's not finite.
+type jsonFloat float64
+
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return json.Marshal(formatValue(v))
+	}
+	return json.Marshal(v)
+}
+
+// jsonFloats converts s into a slice of jsonFloat.
+func jsonFloats(s []float64) []jsonFloat {
+	if s == nil {
+		return nil
+	}
+	fs := make([]jsonFloat, len(s))
+	for i, f := range s {
+		fs[i] = jsonFloat(f)
+	}
+	return fs
+}
+
+// jsonPopulation is the JSON representation of a population.
+type jsonPopulation[T any] struct {
+	Candidates []T
+	Fitness    []jsonFloat
+}
+
+func newJSONPopulation[T any](pop *evolve.Population[T]) *jsonPopulation[T] {
+	if pop == nil {
+		return nil
+	}
+	return &jsonPopulation[T]{Candidates: pop.Candidates, Fitness: jsonFloats(pop.Fitness)}
+}
+
+type (
+	populationStats[T any] evolve.PopulationStats[T]
+	operatorStats          evolve.OperatorStats
+)
+
+// jsonStats is the JSON representation of population statistics, in which
+// the fields shadow the floating-point fields of the embedded statistics.
+type jsonStats[T any] struct {
+	*populationStats[T]
+	BestFitness jsonFloat
+	Mean        jsonFloat
+	StdDev      jsonFloat
+	Coverage    jsonFloat
+	QDScore     jsonFloat
+	HallOfFame  *jsonPopulation[T]
+	Operators   []jsonOperatorStats
+}
+
+type jsonOperatorStats struct {
+	*operatorStats
+	DeltaSum jsonFloat
+}
+
+func newJSONStats[T any](stats *evolve.PopulationStats[T]) jsonStats[T] {
+	js := jsonStats[T]{
+		populationStats: (*populationStats[T])(stats),
+		BestFitness:     jsonFloat(stats.BestFitness),
+		Mean:            jsonFloat(stats.Mean),
+		StdDev:          jsonFloat(stats.StdDev),
+		Coverage:        jsonFloat(stats.Coverage),
+		QDScore:         jsonFloat(stats.QDScore),
+		HallOfFame:      newJSONPopulation(stats.HallOfFame),
+	}
+	for i := range stats.Operators {
+		js.Operators = append(js.Operators, jsonOperatorStats{
+			operatorStats: (*operatorStats)(&stats.Operators[i]),
+			DeltaSum:      jsonFloat(stats.Operators[i].DeltaSum),
+		})
+	}
+	return js
+}
@@ -0,0 +1,156 @@
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+	"github.com/arl/evolve/selection"
+)
+
+func TestEngineRun(t *testing.T) {
+	newEngine := func() *Engine[int] {
+		return &Engine[int]{
+			Factory: evolve.FactoryFunc[int](func(rng *rand.Rand) int {
+				return rng.Intn(100)
+			}),
+			Evaluator: intEvaluator{},
+			Epocher: &Generational[int]{
+				// Best candidate is only seen in the first generation.
+				Operator:  zeroIntMaker{},
+				Evaluator: intEvaluator{},
+				Selection: selection.RouletteWheel[int]{},
+			},
+			EndConditions: []evolve.Condition[int]{
+				condition.GenerationCount[int](10),
+			},
+			Seed:            42,
+			HistoryInterval: 4,
+		}
+	}
+
+	eng := newEngine()
+	res, err := eng.Run(10)
+	check(t, err)
+
+	if res.Generations != 10 {
+		t.Errorf("Generations = %d, want 10", res.Generations)
+	}
+	if res.Evaluations != 100 {
+		t.Errorf("Evaluations = %d, want 100", res.Evaluations)
+	}
+	if res.BestGeneration != 0 || res.BestFitness == 0 || res.Best != int(res.BestFitness) {
+		t.Errorf("Best = %v (fitness %v, generation %d), want best of generation 0",
+			res.Best, res.BestFitness, res.BestGeneration)
+	}
+	if res.Population.Fitness[0] != 0 {
+		t.Errorf("final best fitness = %v, want 0", res.Population.Fitness[0])
+	}
+	if len(res.Satisfied) != 1 {
+		t.Errorf("len(Satisfied) = %d, want 1", len(res.Satisfied))
+	}
+
+	// Generations 0, 4, 8 and the final one.
+	var gens []int
+	for _, stats := range res.History {
+		gens = append(gens, stats.Generation)
+	}
+	if len(gens) != 4 || gens[0] != 0 || gens[1] != 4 || gens[2] != 8 || gens[3] != 9 {
+		t.Errorf("history generations = %v, want [0 4 8 9]", gens)
+	}
+
+	// Same seed gives the same result.
+	res2, err := newEngine().Run(10)
+	check(t, err)
+	if res2.Seed != 42 || res2.Best != res.Best {
+		t.Errorf("seed=%d best=%v, want seed=42 best=%v", res2.Seed, res2.Best, res.Best)
+	}
+}
+
+func TestResultMarshalJSON(t *testing.T) {
+	eng := &Engine[int]{
+		Factory:   zeroFactory,
+		Evaluator: intEvaluator{},
+		Epocher: &Generational[int]{
+			Operator:  zeroIntMaker{},
+			Evaluator: intEvaluator{},
+			Selection: selection.RouletteWheel[int]{},
+		},
+		EndConditions: []evolve.Condition[int]{
+			condition.GenerationCount[int](2),
+		},
+		HistoryInterval: -1,
+	}
+	res, err := eng.Run(4)
+	check(t, err)
+
+	buf, err := json.Marshal(res)
+	check(t, err)
+
+	var got struct {
+		Population  evolve.Population[int]
+		Generations int
+		Evaluations int
+		Satisfied   []string
+		History     []evolve.PopulationStats[int]
+		Seed        int64
+	}
+	check(t, json.Unmarshal(buf, &got))
+
+	if got.Population.Len() != 4 || got.Generations != 2 || got.Evaluations != 8 {
+		t.Errorf("got population size=%d generations=%d evaluations=%d, want 4, 2, 8",
+			got.Population.Len(), got.Generations, got.Evaluations)
+	}
+	if len(got.Satisfied) != 1 || got.Satisfied[0] != "Reached 2 generations" {
+		t.Errorf("Satisfied = %q, want [\"Reached 2 generations\"]", got.Satisfied)
+	}
+	if got.History != nil {
+		t.Errorf("History = %v, want nil (history disabled)", got.History)
+	}
+	if got.Seed == 0 || got.Seed != eng.Seed {
+		t.Errorf("Seed = %d, want %d", got.Seed, eng.Seed)
+	}
+}
+
+func TestResultMarshalJSONNonFinite(t *testing.T) {
+	res := &Result[int]{
+		Population:  &evolve.Population[int]{Candidates: []int{1, 2}, Fitness: []float64{1, math.Inf(1)}},
+		Best:        1,
+		BestFitness: math.Inf(-1),
+		History: []evolve.PopulationStats[int]{{
+			BestFitness: 1,
+			Mean:        math.Inf(1),
+			StdDev:      math.NaN(),
+			HallOfFame:  &evolve.Population[int]{Candidates: []int{3}, Fitness: []float64{math.Inf(1)}},
+			Operators:   []evolve.OperatorStats{{Name: "op", Applied: 2, DeltaSum: math.Inf(1)}},
+		}},
+	}
+
+	buf, err := json.Marshal(res)
+	check(t, err)
+
+	var got map[string]any
+	check(t, json.Unmarshal(buf, &got))
+	if got["BestFitness"] != "-Inf" {
+		t.Errorf("BestFitness = %v, want \"-Inf\"", got["BestFitness"])
+	}
+	if pop := got["Population"].(map[string]any); fmt.Sprint(pop["Fitness"]) != "[1 +Inf]" {
+		t.Errorf("Population.Fitness = %v, want [1 +Inf]", pop["Fitness"])
+	}
+
+	stats := got["History"].([]any)[0].(map[string]any)
+	if stats["BestFitness"] != 1.0 || stats["Mean"] != "+Inf" || stats["StdDev"] != "NaN" {
+		t.Errorf("got stats %v, want BestFitness 1, Mean \"+Inf\" and StdDev \"NaN\"", stats)
+	}
+	if hof := stats["HallOfFame"].(map[string]any); fmt.Sprint(hof["Fitness"]) != "[+Inf]" {
+		t.Errorf("HallOfFame.Fitness = %v, want [+Inf]", hof["Fitness"])
+	}
+	op := stats["Operators"].([]any)[0].(map[string]any)
+	if op["Name"] != "op" || op["Applied"] != 2.0 || op["DeltaSum"] != "+Inf" {
+		t.Errorf("got operator stats %v, want name, applications and \"+Inf\" delta sum", op)
+	}
+}
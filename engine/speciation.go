@@ -0,0 +1,292 @@
+package engine
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+
+	"github.com/arl/evolve"
+)
+
+// Speciation implements a NEAT-like speciated evolutionary algorithm.
+//
+// At each epoch, candidates are grouped into species: a candidate belongs to
+// the first species whose representative is within the compatibility Threshold
+// of it. If no species is compatible, a new species is created, with the
+// candidate as representative. Each species is then allotted a number of
+// offspring proportional to its adjusted fitness, that is the fitness of its
+// members, shared among them. This protects innovation, since a new species
+// doesn't have to directly compete with the rest of the population.
+//
+// Breeding happens within each species, with the same Selection and Operator
+// split than Generational: candidates are selected among the members of a
+// species, and the operator is applied to them.
+//
+// Species that haven't improved for a given number of generations are culled.
+type Speciation[T any] struct {
+	Operator  evolve.Operator[T]
+	Evaluator evolve.Evaluator[T]
+	Selection evolve.Selection[T]
+
+	// Compatibility returns the compatibility distance between 2 candidates,
+	// that is how dissimilar they are.
+	Compatibility func(a, b T) float64
+
+	// Threshold is the compatibility distance under which 2 candidates are
+	// considered to be part of the same species.
+	Threshold float64
+
+	// TargetSpecies is the number of species to aim for. If it's greater than
+	// 0, Threshold is automatically adjusted by ThresholdStep at each
+	// generation, in order to get closer to that number of species.
+	TargetSpecies int
+	ThresholdStep float64
+
+	// Stagnation is the number of generations a species is allowed to go
+	// without any improvement of its best fitness before being culled. The
+	// species holding the fittest candidate of the population is never culled.
+	// If 0, species are never culled.
+	Stagnation int
+
+	// Elites is the number of fittest candidates of each species that are
+	// preserved, unchanged, into the next generation.
+	Elites int
+
+	// Number of concurrent processes to use (defaults to the number of cores).
+	Concurrency int
+
+	init    bool
+	species []*species[T]
+}
+
+type species[T any] struct {
+	rep      T                     // representative
+	members  *evolve.Population[T] // sorted, fittest first
+	best     float64               // best fitness ever reached
+	stagnant int                   // generations since last improvement
+	fresh    bool                  // created this generation
+}
+
+// Epoch performs a single step/iteration of the evolutionary process.
+//
+// pop is the population to evolve, sorted by fitness, the fittest first.
+//
+// Returns the updated population after the evolutionary process has proceeded
+// by one step/iteration.
+func (e *Speciation[T]) Epoch(pop *evolve.Population[T], rng *rand.Rand) *evolve.Population[T] {
+	if !e.init {
+		if e.Concurrency == 0 {
+			e.Concurrency = runtime.NumCPU()
+		}
+		e.init = true
+	}
+
+	natural := e.Evaluator.IsNatural()
+	top := e.speciate(pop, rng)
+	e.cull(top, natural)
+	e.adjustThreshold()
+
+	counts := e.allotOffspring(pop.Len(), natural)
+
+	nextpop := make([]T, 0, pop.Len())
+	for i, s := range e.species {
+		n := counts[i]
+		if n == 0 {
+			continue
+		}
+
+		// Perform elitism within the species.
+		nelites := minInt(e.Elites, n, s.members.Len())
+		nextpop = append(nextpop, s.members.Candidates[:nelites]...)
+
+		// Breed the rest from the species members.
+		if n -= nelites; n > 0 {
+			selected := e.Selection.Select(s.members, natural, n, rng)
+			nextpop = append(nextpop, e.Operator.Apply(selected, rng)...)
+		}
+	}
+
+	return evolve.EvaluatePopulation(nextpop, e.Evaluator, e.Concurrency)
+}
+
+// AnnotateStats reports the number of species found during the last epoch.
+// Since candidates are assigned to species when they're bred from, that's the
+// number of species of the previous generation: the count lags one generation
+// behind the annotated population, and is 0 for the initial population and
+// after a restart.
+func (e *Speciation[T]) AnnotateStats(stats *evolve.PopulationStats[T]) {
+	stats.Species = len(e.species)
+}
+
+// speciate assigns each candidate of pop to a species, creating new species
+// as needed, and removes species left without members. It returns the species
+// holding the fittest candidate.
+func (e *Speciation[T]) speciate(pop *evolve.Population[T], rng *rand.Rand) *species[T] {
+	for _, s := range e.species {
+		s.members = evolve.NewPopulation[T](0)
+		s.fresh = false
+	}
+
+	// Since pop is sorted, members get sorted as well.
+	for i, cand := range pop.Candidates {
+		var found *species[T]
+		for _, s := range e.species {
+			if e.Compatibility(cand, s.rep) < e.Threshold {
+				found = s
+				break
+			}
+		}
+		if found == nil {
+			found = &species[T]{
+				rep:     cand,
+				members: evolve.NewPopulation[T](0),
+				best:    pop.Fitness[i],
+				fresh:   true,
+			}
+			e.species = append(e.species, found)
+		}
+		found.members.Candidates = append(found.members.Candidates, cand)
+		found.members.Fitness = append(found.members.Fitness, pop.Fitness[i])
+	}
+
+	alive := e.species[:0]
+	for _, s := range e.species {
+		if s.members.Len() == 0 {
+			continue
+		}
+		// Pick a random member as representative for the next generation.
+		s.rep = s.members.Candidates[rng.Intn(s.members.Len())]
+		alive = append(alive, s)
+	}
+	e.species = alive
+
+	// The first candidate of pop is the fittest.
+	for _, s := range e.species {
+		if s.members.Len() > 0 && s.members.Fitness[0] == pop.Fitness[0] {
+			return s
+		}
+	}
+	return nil
+}
+
+// cull updates the stagnation counter of each species and removes the species
+// that have stagnated for too long, except top.
+func (e *Speciation[T]) cull(top *species[T], natural bool) {
+	alive := e.species[:0]
+	for _, s := range e.species {
+		best := s.members.Fitness[0]
+		switch {
+		case s.fresh:
+		case natural && best > s.best, !natural && best < s.best:
+			s.best = best
+			s.stagnant = 0
+		default:
+			s.stagnant++
+		}
+
+		if s != top && e.Stagnation > 0 && s.stagnant >= e.Stagnation {
+			continue
+		}
+		alive = append(alive, s)
+	}
+	e.species = alive
+}
+
+// adjustThreshold adjusts the compatibility threshold so that the number of
+// species gets closer to the target.
+func (e *Speciation[T]) adjustThreshold() {
+	if e.TargetSpecies <= 0 {
+		return
+	}
+	switch {
+	case len(e.species) < e.TargetSpecies:
+		e.Threshold -= e.ThresholdStep
+	case len(e.species) > e.TargetSpecies:
+		e.Threshold += e.ThresholdStep
+	}
+	if e.Threshold < e.ThresholdStep {
+		e.Threshold = e.ThresholdStep
+	}
+}
+
+// Reset forgets all species. It's called by the engine on restart, so that the
+// restarted population gets speciated from scratch.
+func (e *Speciation[T]) Reset() {
+	e.species = nil
+}
+
+// allotOffspring returns the number of offspring of each species, the total
+// being n, proportionally to the adjusted fitness of each species.
+func (e *Speciation[T]) allotOffspring(n int, natural bool) []int {
+	// Fitness scores are shifted so that the least fit candidate of the
+	// population scores 0, and fitter candidates score more, whatever the sign
+	// of fitness scores and their naturalness.
+	worst := math.Inf(1)
+	if !natural {
+		worst = math.Inf(-1)
+	}
+	for _, s := range e.species {
+		for _, f := range s.members.Fitness {
+			if natural {
+				worst = math.Min(worst, f)
+			} else {
+				worst = math.Max(worst, f)
+			}
+		}
+	}
+
+	// The adjusted fitness of a candidate is its shifted fitness divided by
+	// the size of its species, so the sum of adjusted fitness over a species
+	// is the mean shifted fitness of its members.
+	shares := make([]float64, len(e.species))
+	var total float64
+	for i, s := range e.species {
+		for _, f := range s.members.Fitness {
+			if natural {
+				shares[i] += f - worst
+			} else {
+				shares[i] += worst - f
+			}
+		}
+		shares[i] /= float64(s.members.Len())
+		total += shares[i]
+	}
+
+	counts := make([]int, len(e.species))
+	fracs := make([]float64, len(e.species))
+	allotted := 0
+	for i := range shares {
+		share := 1 / float64(len(shares))
+		if total > 0 {
+			share = shares[i] / total
+		}
+		exact := share * float64(n)
+		counts[i] = int(math.Floor(exact))
+		fracs[i] = exact - float64(counts[i])
+		allotted += counts[i]
+	}
+
+	// Distribute the remainder to the species with the largest fractional
+	// parts.
+	idx := make([]int, len(counts))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(i, j int) bool { return fracs[idx[i]] > fracs[idx[j]] })
+	for i := 0; allotted < n; i++ {
+		counts[idx[i%len(idx)]]++
+		allotted++
+	}
+	return counts
+}
+
+func minInt(vals ...int) int {
+	m := vals[0]
+	for _, v := range vals[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+	"github.com/arl/evolve/selection"
+)
+
+func distance(a, b float64) float64 { return math.Abs(a - b) }
+
+func TestSpeciationGroupsCandidates(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	e := &Speciation[float64]{
+		Operator:      gaussianStep(0),
+		Evaluator:     bimodalEvaluator,
+		Selection:     selection.RouletteWheel[float64]{},
+		Compatibility: distance,
+		Threshold:     5,
+	}
+
+	pop := evolve.NewPopulation[float64](6)
+	copy(pop.Candidates, []float64{20, 21, 80, 78, 79, 50})
+	for i, x := range pop.Candidates {
+		pop.Fitness[i] = bimodalEvaluator.Fitness(x, pop.Candidates)
+	}
+
+	next := e.Epoch(pop, rng)
+	if next.Len() != pop.Len() {
+		t.Errorf("population size = %d, want %d", next.Len(), pop.Len())
+	}
+
+	var stats evolve.PopulationStats[float64]
+	e.AnnotateStats(&stats)
+	if stats.Species != 3 {
+		t.Errorf("species = %d, want 3", stats.Species)
+	}
+}
+
+func TestSpeciationTargetSpecies(t *testing.T) {
+	const target = 4
+
+	var species []int
+	obs := ObserverFunc(func(stats *evolve.PopulationStats[float64]) {
+		species = append(species, stats.Species)
+	})
+
+	eng := Engine[float64]{
+		Factory: evolve.FactoryFunc[float64](func(rng *rand.Rand) float64 {
+			return rng.Float64() * 100
+		}),
+		Evaluator: bimodalEvaluator,
+		Epocher: &Speciation[float64]{
+			Operator:      gaussianStep(1),
+			Evaluator:     bimodalEvaluator,
+			Selection:     selection.RouletteWheel[float64]{},
+			Compatibility: distance,
+			Threshold:     1,
+			TargetSpecies: target,
+			ThresholdStep: 0.5,
+			Stagnation:    15,
+			Elites:        1,
+		},
+		EndConditions: []evolve.Condition[float64]{
+			condition.GenerationCount[float64](100),
+		},
+		Observers: []Observer[float64]{obs},
+		RNG:       rand.New(rand.NewSource(99)),
+	}
+
+	pop, _, err := eng.Evolve(100)
+	check(t, err)
+	if pop.Len() != 100 {
+		t.Errorf("population size = %d, want 100", pop.Len())
+	}
+
+	// Number of species at the last generation should be close to the target.
+	last := species[len(species)-1]
+	if last < target-2 || last > target+2 {
+		t.Errorf("species = %d, want close to %d (history: %v)", last, target, species)
+	}
+}
+
+func TestSpeciationNegativeFitness(t *testing.T) {
+	newSpecies := func(fitness ...float64) *species[float64] {
+		members := evolve.NewPopulation[float64](len(fitness))
+		copy(members.Fitness, fitness)
+		return &species[float64]{members: members}
+	}
+
+	tests := []struct {
+		name    string
+		natural bool
+		fitness [][]float64
+		want    []int
+	}{
+		{"natural", true, [][]float64{{-10, -10}, {-2, -2}, {-6, -6}}, []int{0, 8, 4}},
+		{"non-natural", false, [][]float64{{-5, -5}, {-1.5, -1.5}, {-3.25, -3.25}}, []int{8, 0, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Speciation[float64]{}
+			for _, f := range tt.fitness {
+				e.species = append(e.species, newSpecies(f...))
+			}
+
+			counts := e.allotOffspring(12, tt.natural)
+			if fmt.Sprint(counts) != fmt.Sprint(tt.want) {
+				t.Errorf("offspring counts = %v, want %v", counts, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,364 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/arl/evolve"
+)
+
+// DefaultColumns are the columns written by stats loggers when no columns are
+// specified.
+var DefaultColumns = []string{
+	"generation",
+	"best_fitness",
+	"mean",
+	"stddev",
+	"size",
+	"evaluations",
+	"elapsed",
+}
+
+// statsRecord holds the population statistics written in stats logs, in which
+// the best candidate is formatted.
+type statsRecord = evolve.PopulationStats[string]
+
+type column struct {
+	get func(*statsRecord) any
+	set func(*statsRecord, string) error
+}
+
+func intColumn(f func(*statsRecord) *int) column {
+	return column{
+		get: func(r *statsRecord) any { return *f(r) },
+		set: func(r *statsRecord, s string) (err error) {
+			*f(r), err = strconv.Atoi(s)
+			return
+		},
+	}
+}
+
+func floatColumn(f func(*statsRecord) *float64) column {
+	return column{
+		get: func(r *statsRecord) any { return *f(r) },
+		set: func(r *statsRecord, s string) (err error) {
+			*f(r), err = strconv.ParseFloat(s, 64)
+			return
+		},
+	}
+}
+
+// columns holds all the columns that can be written into stats logs.
+var columns = map[string]column{
+	"generation":   intColumn(func(r *statsRecord) *int { return &r.Generation }),
+	"best_fitness": floatColumn(func(r *statsRecord) *float64 { return &r.BestFitness }),
+	"mean":         floatColumn(func(r *statsRecord) *float64 { return &r.Mean }),
+	"stddev":       floatColumn(func(r *statsRecord) *float64 { return &r.StdDev }),
+	"size":         intColumn(func(r *statsRecord) *int { return &r.Size }),
+	"elites":       intColumn(func(r *statsRecord) *int { return &r.NumElites }),
+	"evaluations":  intColumn(func(r *statsRecord) *int { return &r.Evaluations }),
+	"restarts":     intColumn(func(r *statsRecord) *int { return &r.Restarts }),
+	"species":      intColumn(func(r *statsRecord) *int { return &r.Species }),
+	"coverage":     floatColumn(func(r *statsRecord) *float64 { return &r.Coverage }),
+	"qd_score":     floatColumn(func(r *statsRecord) *float64 { return &r.QDScore }),
+	"natural": {
+		get: func(r *statsRecord) any { return r.Natural },
+		set: func(r *statsRecord, s string) (err error) {
+			r.Natural, err = strconv.ParseBool(s)
+			return
+		},
+	},
+	// elapsed is expressed in seconds.
+	"elapsed": {
+		get: func(r *statsRecord) any { return r.Elapsed.Seconds() },
+		set: func(r *statsRecord, s string) error {
+			sec, err := strconv.ParseFloat(s, 64)
+			r.Elapsed = time.Duration(sec * float64(time.Second))
+			return err
+		},
+	},
+	"best": {
+		get: func(r *statsRecord) any { return r.Best },
+		set: func(r *statsRecord, s string) error {
+			r.Best = s
+			return nil
+		},
+	},
+}
+
+// statsLogger holds the configuration and state shared by stats loggers.
+type statsLogger[T any] struct {
+	// Columns lists the names of the columns to write, in order. If empty,
+	// DefaultColumns are written. Valid column names are: generation,
+	// best_fitness, mean, stddev, natural, size, elites, evaluations,
+	// restarts, species, coverage, qd_score, elapsed (in seconds) and best.
+	Columns []string
+
+	// Format formats the best candidate, for the 'best' column. If nil,
+	// candidates are formatted with fmt.Sprint.
+	Format func(T) string
+
+	// FlushInterval is the interval, in generations, at which the buffered
+	// log is flushed to the underlying writer. If 0, the log is flushed at
+	// every generation.
+	FlushInterval int
+
+	n   int
+	err error
+}
+
+func (l *statsLogger[T]) columns() ([]string, error) {
+	cols := l.Columns
+	if len(cols) == 0 {
+		cols = DefaultColumns
+	}
+	for _, name := range cols {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("unknown stats column %q", name)
+		}
+	}
+	return cols, nil
+}
+
+func (l *statsLogger[T]) record(stats *evolve.PopulationStats[T]) *statsRecord {
+	var best string
+	if l.Format != nil {
+		best = l.Format(stats.Best)
+	} else {
+		best = fmt.Sprint(stats.Best)
+	}
+
+	return &statsRecord{
+		Best:        best,
+		BestFitness: stats.BestFitness,
+		Mean:        stats.Mean,
+		StdDev:      stats.StdDev,
+		Natural:     stats.Natural,
+		Size:        stats.Size,
+		NumElites:   stats.NumElites,
+		Generation:  stats.Generation,
+		Elapsed:     stats.Elapsed,
+		Evaluations: stats.Evaluations,
+		Restarts:    stats.Restarts,
+		Species:     stats.Species,
+		Coverage:    stats.Coverage,
+		QDScore:     stats.QDScore,
+	}
+}
+
+// shouldFlush reports whether the log should be flushed after the current
+// generation.
+func (l *statsLogger[T]) shouldFlush() bool {
+	l.n++
+	return l.FlushInterval <= 1 || l.n%l.FlushInterval == 0
+}
+
+// Err returns the first error that occurred while writing the log, if any.
+func (l *statsLogger[T]) Err() error { return l.err }
+
+// CSVLogger is an Observer writing population statistics to a CSV file, one
+// row per generation, preceded by a header row holding the column names.
+//
+// Since Observe can't return errors, writing stops at the first error, which
+// can be retrieved with Err.
+type CSVLogger[T any] struct {
+	statsLogger[T]
+
+	w    *csv.Writer
+	cols []string
+}
+
+// NewCSVLogger returns a CSVLogger writing to w.
+func NewCSVLogger[T any](w io.Writer) *CSVLogger[T] {
+	return &CSVLogger[T]{w: csv.NewWriter(w)}
+}
+
+// Observe writes a row of population statistics.
+func (l *CSVLogger[T]) Observe(stats *evolve.PopulationStats[T]) {
+	if l.err != nil {
+		return
+	}
+	if l.cols == nil {
+		if l.cols, l.err = l.columns(); l.err != nil {
+			return
+		}
+		if l.err = l.w.Write(l.cols); l.err != nil {
+			return
+		}
+	}
+
+	rec := l.record(stats)
+	row := make([]string, len(l.cols))
+	for i, name := range l.cols {
+		row[i] = formatValue(columns[name].get(rec))
+	}
+	if l.err = l.w.Write(row); l.err != nil {
+		return
+	}
+	if l.shouldFlush() {
+		l.err = l.Flush()
+	}
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (l *CSVLogger[T]) Flush() error {
+	l.w.Flush()
+	return l.w.Error()
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	}
+	panic(fmt.Sprintf("unexpected column value type %T", v))
+}
+
+// JSONLogger is an Observer writing population statistics in the JSON Lines
+// format, that is one JSON object per generation and per line. Each object
+// holds one field per column. Since JSON numbers can't represent them, NaN and
+// infinite values are written as the strings "NaN", "+Inf" and "-Inf".
+//
+// Since Observe can't return errors, writing stops at the first error, which
+// can be retrieved with Err.
+type JSONLogger[T any] struct {
+	statsLogger[T]
+
+	w    *bufio.Writer
+	cols []string
+}
+
+// NewJSONLogger returns a JSONLogger writing to w.
+func NewJSONLogger[T any](w io.Writer) *JSONLogger[T] {
+	return &JSONLogger[T]{w: bufio.NewWriter(w)}
+}
+
+// Observe writes a line of population statistics.
+func (l *JSONLogger[T]) Observe(stats *evolve.PopulationStats[T]) {
+	if l.err != nil {
+		return
+	}
+	if l.cols == nil {
+		if l.cols, l.err = l.columns(); l.err != nil {
+			return
+		}
+	}
+
+	rec := l.record(stats)
+	obj := make(map[string]any, len(l.cols))
+	for _, name := range l.cols {
+		obj[name] = jsonValue(columns[name].get(rec))
+	}
+	buf, err := json.Marshal(obj)
+	if err != nil {
+		l.err = err
+		return
+	}
+	if _, l.err = l.w.Write(append(buf, '\n')); l.err != nil {
+		return
+	}
+	if l.shouldFlush() {
+		l.err = l.Flush()
+	}
+}
+
+// jsonValue returns v as a string if it's a non-finite float, or v otherwise.
+func jsonValue(v any) any {
+	if f, ok := v.(float64); ok && (math.IsNaN(f) || math.IsInf(f, 0)) {
+		return formatValue(f)
+	}
+	return v
+}
+
+// Flush writes any buffered data to the underlying writer.
+func (l *JSONLogger[T]) Flush() error {
+	return l.w.Flush()
+}
+
+// ReadCSVStats reads population statistics from a CSV log, as written by
+// CSVLogger. Best candidates are returned in their formatted form. Statistics
+// that are not part of the log are left to their zero value.
+func ReadCSVStats(r io.Reader) ([]evolve.PopulationStats[string], error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range header {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("unknown stats column %q", name)
+		}
+	}
+
+	var all []evolve.PopulationStats[string]
+	for {
+		row, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		var rec statsRecord
+		for i, name := range header {
+			if err := columns[name].set(&rec, row[i]); err != nil {
+				return nil, fmt.Errorf("column %q: %v", name, err)
+			}
+		}
+		all = append(all, rec)
+	}
+	return all, nil
+}
+
+// ReadJSONStats reads population statistics from a JSON Lines log, as written
+// by JSONLogger. Best candidates are returned in their formatted form.
+// Statistics that are not part of the log are left to their zero value.
+func ReadJSONStats(r io.Reader) ([]evolve.PopulationStats[string], error) {
+	var all []evolve.PopulationStats[string]
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, 1<<24)
+	for s.Scan() {
+		line := bytes.TrimSpace(s.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(line, &obj); err != nil {
+			return nil, err
+		}
+		var rec statsRecord
+		for name, raw := range obj {
+			col, ok := columns[name]
+			if !ok {
+				return nil, fmt.Errorf("unknown stats column %q", name)
+			}
+			val := string(raw)
+			if len(raw) > 0 && raw[0] == '"' {
+				if err := json.Unmarshal(raw, &val); err != nil {
+					return nil, fmt.Errorf("column %q: %v", name, err)
+				}
+			}
+			if err := col.set(&rec, val); err != nil {
+				return nil, fmt.Errorf("column %q: %v", name, err)
+			}
+		}
+		all = append(all, rec)
+	}
+	return all, s.Err()
+}
@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/arl/evolve"
+)
+
+func testStats(n int) []evolve.PopulationStats[[]int] {
+	all := make([]evolve.PopulationStats[[]int], n)
+	for i := range all {
+		all[i] = evolve.PopulationStats[[]int]{
+			Best:        []int{i, i + 1},
+			BestFitness: float64(i) + 0.5,
+			Mean:        float64(i) / 3,
+			StdDev:      1.25,
+			Natural:     true,
+			Size:        10,
+			Generation:  i,
+			Elapsed:     time.Duration(i) * time.Millisecond,
+			Evaluations: 10 * (i + 1),
+		}
+	}
+	return all
+}
+
+type flushObserver interface {
+	Observer[[]int]
+	Flush() error
+	Err() error
+}
+
+func TestStatsLoggers(t *testing.T) {
+	format := func(cand []int) string { return fmt.Sprintf("%d-%d", cand[0], cand[1]) }
+	cols := []string{"generation", "best_fitness", "mean", "stddev", "natural", "size", "evaluations", "elapsed", "best"}
+
+	tests := []struct {
+		name string
+		new  func(*bytes.Buffer) flushObserver
+		read func(*bytes.Buffer) ([]evolve.PopulationStats[string], error)
+	}{
+		{
+			name: "csv",
+			new: func(buf *bytes.Buffer) flushObserver {
+				l := NewCSVLogger[[]int](buf)
+				l.Columns = cols
+				l.Format = format
+				l.FlushInterval = 2
+				return l
+			},
+			read: func(buf *bytes.Buffer) ([]evolve.PopulationStats[string], error) { return ReadCSVStats(buf) },
+		},
+		{
+			name: "json",
+			new: func(buf *bytes.Buffer) flushObserver {
+				l := NewJSONLogger[[]int](buf)
+				l.Columns = cols
+				l.Format = format
+				l.FlushInterval = 2
+				return l
+			},
+			read: func(buf *bytes.Buffer) ([]evolve.PopulationStats[string], error) { return ReadJSONStats(buf) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			l := tt.new(&buf)
+			all := testStats(5)
+			for i := range all {
+				l.Observe(&all[i])
+			}
+			check(t, l.Err())
+
+			// 4 generations have been flushed.
+			flushed, err := tt.read(bytes.NewBuffer(buf.Bytes()))
+			check(t, err)
+			if len(flushed) != 4 {
+				t.Fatalf("read %d flushed generations, want 4", len(flushed))
+			}
+
+			check(t, l.Flush())
+			got, err := tt.read(&buf)
+			check(t, err)
+			if len(got) != len(all) {
+				t.Fatalf("read %d generations, want %d", len(got), len(all))
+			}
+			for i := range all {
+				want := evolve.PopulationStats[string]{
+					Best:        format(all[i].Best),
+					BestFitness: all[i].BestFitness,
+					Mean:        all[i].Mean,
+					StdDev:      all[i].StdDev,
+					Natural:     all[i].Natural,
+					Size:        all[i].Size,
+					Generation:  all[i].Generation,
+					Elapsed:     all[i].Elapsed,
+					Evaluations: all[i].Evaluations,
+				}
+				if fmt.Sprint(got[i]) != fmt.Sprint(want) {
+					t.Errorf("generation %d:\ngot  %+v\nwant %+v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestStatsLoggersDefaultColumns(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewCSVLogger[[]int](&buf)
+	all := testStats(1)
+	l.Observe(&all[0])
+	check(t, l.Err())
+
+	header := strings.SplitN(buf.String(), "\n", 2)[0]
+	if want := strings.Join(DefaultColumns, ","); header != want {
+		t.Errorf("header = %q, want %q", header, want)
+	}
+}
+
+func TestStatsLoggersUnknownColumn(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewJSONLogger[[]int](&buf)
+	l.Columns = []string{"generation", "foo"}
+	all := testStats(1)
+	l.Observe(&all[0])
+	if l.Err() == nil {
+		t.Errorf("want error for unknown column, got nil")
+	}
+}
+
+func TestStatsLoggersNonFinite(t *testing.T) {
+	all := testStats(3)
+	all[0].BestFitness = math.Inf(1)
+	all[1].BestFitness = math.Inf(-1)
+	all[1].Mean = math.NaN()
+
+	for _, name := range []string{"csv", "json"} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				buf  bytes.Buffer
+				l    flushObserver
+				read func(io.Reader) ([]evolve.PopulationStats[string], error)
+			)
+			if name == "csv" {
+				l, read = NewCSVLogger[[]int](&buf), ReadCSVStats
+			} else {
+				l, read = NewJSONLogger[[]int](&buf), ReadJSONStats
+			}
+			for i := range all {
+				l.Observe(&all[i])
+			}
+			check(t, l.Err())
+			check(t, l.Flush())
+
+			got, err := read(&buf)
+			check(t, err)
+			if len(got) != len(all) {
+				t.Fatalf("read %d generations, want %d", len(got), len(all))
+			}
+			if !math.IsInf(got[0].BestFitness, 1) || !math.IsInf(got[1].BestFitness, -1) || !math.IsNaN(got[1].Mean) {
+				t.Errorf("non-finite values not preserved, got %+v and %+v", got[0], got[1])
+			}
+			if got[2].BestFitness != all[2].BestFitness {
+				t.Errorf("best fitness = %v, want %v", got[2].BestFitness, all[2].BestFitness)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package factory
+
+import (
+	"math/rand"
+
+	"github.com/arl/evolve"
+)
+
+// Float64s creates random real-valued vectors, within bounds.
+type Float64s struct {
+	// Bounds holds the range of each dimension of the generated vectors.
+	Bounds evolve.Bounds
+
+	// Sampler distributes the generated vectors in the search space. If nil,
+	// vectors are uniformly distributed.
+	Sampler Sampler
+}
+
+// New creates a random vector.
+func (f *Float64s) New(rng *rand.Rand) []float64 {
+	x := make([]float64, f.Bounds.Dim())
+	if f.Sampler == nil {
+		Uniform{}.Sample(x, rng)
+	} else {
+		f.Sampler.Sample(x, rng)
+	}
+	for i := range x {
+		x[i] = f.Bounds.Min[i] + x[i]*(f.Bounds.Max[i]-f.Bounds.Min[i])
+	}
+	return x
+}
@@ -0,0 +1,113 @@
+package factory
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFloat64s(t *testing.T) {
+	bounds, err := evolve.NewBounds([]float64{-5, 0, 10}, []float64{5, 1, 10})
+	require.NoError(t, err)
+
+	samplers := map[string]Sampler{
+		"default":         nil,
+		"uniform":         Uniform{},
+		"latin hypercube": &LatinHypercube{N: 10},
+		"halton":          &Halton{},
+		"sobol":           &Sobol{},
+		"opposition":      &Opposition{},
+	}
+	for name, s := range samplers {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(99))
+			f := &Float64s{Bounds: bounds, Sampler: s}
+			for _, x := range evolve.GeneratePopulation[[]float64](f, 100, rng) {
+				require.Len(t, x, 3)
+				require.Truef(t, bounds.Contains(x), "generated vector out of bounds: %v", x)
+			}
+		})
+	}
+}
+
+// checkStrata checks that, in each dimension, each of the n intervals of width
+// 1/n contains exactly one point, except those listed in empty which must be
+// empty.
+func checkStrata(t *testing.T, pts [][]float64, n int, empty ...int) {
+	t.Helper()
+
+	for d := range pts[0] {
+		counts := make([]int, n)
+		for _, x := range pts {
+			counts[int(x[d]*float64(n))]++
+		}
+		for i, c := range counts {
+			want := 1
+			for _, e := range empty {
+				if i == e {
+					want = 0
+				}
+			}
+			assert.Equalf(t, want, c, "dimension %d, interval %d", d, i)
+		}
+	}
+}
+
+func sample(s Sampler, n, d int, rng *rand.Rand) [][]float64 {
+	pts := make([][]float64, n)
+	for i := range pts {
+		pts[i] = make([]float64, d)
+		s.Sample(pts[i], rng)
+	}
+	return pts
+}
+
+func TestLatinHypercube(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	s := &LatinHypercube{N: 20}
+
+	// Each batch is stratified.
+	checkStrata(t, sample(s, 20, 4, rng), 20)
+	checkStrata(t, sample(s, 20, 4, rng), 20)
+}
+
+func TestHalton(t *testing.T) {
+	s := &Halton{}
+	pts := sample(s, 4, 3, nil)
+
+	assert.Equal(t, []float64{1. / 2, 1. / 3, 1. / 5}, pts[0])
+	assert.Equal(t, []float64{1. / 4, 2. / 3, 2. / 5}, pts[1])
+	assert.InDeltaSlice(t, []float64{3. / 4, 1. / 9, 3. / 5}, pts[2], 1e-12)
+	assert.InDeltaSlice(t, []float64{1. / 8, 4. / 9, 4. / 5}, pts[3], 1e-12)
+
+	assert.Equal(t, []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}, primes(nil, 10))
+}
+
+func TestSobol(t *testing.T) {
+	s := &Sobol{}
+
+	pts := sample(s, 3, 2, nil)
+	assert.Equal(t, [][]float64{{0.5, 0.5}, {0.75, 0.25}, {0.25, 0.75}}, pts)
+
+	// Together with the skipped origin, the first 2^k points of the sequence
+	// are stratified in each dimension.
+	s = &Sobol{}
+	checkStrata(t, sample(s, 63, MaxSobolDim, nil), 64, 0)
+
+	assert.Panics(t, func() { s.Sample(make([]float64, MaxSobolDim+1), nil) })
+}
+
+func TestOpposition(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	s := &Opposition{}
+
+	pts := sample(s, 10, 3, rng)
+	for i := 0; i < len(pts); i += 2 {
+		for d := range pts[i] {
+			assert.InDelta(t, 1, pts[i][d]+pts[i+1][d], 1e-12)
+		}
+	}
+}
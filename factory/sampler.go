@@ -0,0 +1,165 @@
+package factory
+
+import (
+	"math/rand"
+	"sync"
+)
+
+// A Sampler generates points in the unit hypercube [0, 1]^d. Samplers define
+// the initialization scheme of Float64s.
+type Sampler interface {
+	// Sample fills x with the coordinates of the next point.
+	Sample(x []float64, rng *rand.Rand)
+}
+
+// Uniform is a Sampler generating uniformly distributed points.
+type Uniform struct{}
+
+// Sample fills x with uniformly distributed coordinates.
+func (Uniform) Sample(x []float64, rng *rand.Rand) {
+	for i := range x {
+		x[i] = rng.Float64()
+	}
+}
+
+// LatinHypercube is a Sampler performing Latin hypercube sampling: points are
+// generated by batches of N, such that, in each dimension, each of the N
+// equally sized intervals contains exactly one point of the batch.
+//
+// N is typically set to the population size, so that the initial population
+// covers evenly the range of each dimension.
+type LatinHypercube struct {
+	// N is the number of points of a batch.
+	N int
+
+	mu    sync.Mutex
+	batch [][]float64
+}
+
+// Sample fills x with the coordinates of the next point of the current batch.
+func (s *LatinHypercube) Sample(x []float64, rng *rand.Rand) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.batch) == 0 || len(s.batch[0]) != len(x) {
+		s.fill(len(x), rng)
+	}
+	copy(x, s.batch[0])
+	s.batch = s.batch[1:]
+}
+
+// fill generates a new batch of N points of d dimensions.
+func (s *LatinHypercube) fill(d int, rng *rand.Rand) {
+	n := s.N
+	if n <= 0 {
+		n = 1
+	}
+	s.batch = make([][]float64, n)
+	for i := range s.batch {
+		s.batch[i] = make([]float64, d)
+	}
+	for j := 0; j < d; j++ {
+		for i, stratum := range rng.Perm(n) {
+			s.batch[i][j] = (float64(stratum) + rng.Float64()) / float64(n)
+		}
+	}
+}
+
+// Halton is a Sampler generating the points of the Halton quasi-random
+// sequence, in which the i-th dimension is the van der Corput sequence in the
+// base of the i-th prime number. The sequence is deterministic, rng is not
+// used.
+//
+// Quasi-random sequences cover the search space more evenly than uniformly
+// distributed points. The Halton sequence is best suited to a small number of
+// dimensions, since correlations appear between dimensions in large bases.
+type Halton struct {
+	mu     sync.Mutex
+	index  uint64
+	primes []uint64
+}
+
+// Sample fills x with the coordinates of the next point of the sequence.
+func (s *Halton) Sample(x []float64, _ *rand.Rand) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Skip the origin, the first point of the sequence.
+	s.index++
+	s.primes = primes(s.primes, len(x))
+	for i := range x {
+		x[i] = radicalInverse(s.index, s.primes[i])
+	}
+}
+
+// radicalInverse returns the radical inverse of n in the given base.
+func radicalInverse(n, base uint64) float64 {
+	var inv float64
+	f := 1 / float64(base)
+	for w := f; n > 0; w *= f {
+		inv += float64(n%base) * w
+		n /= base
+	}
+	return inv
+}
+
+// primes extends ps, the list of the first prime numbers, up to n primes.
+func primes(ps []uint64, n int) []uint64 {
+	next := uint64(2)
+	if len(ps) > 0 {
+		next = ps[len(ps)-1] + 1
+	}
+	for ; len(ps) < n; next++ {
+		prime := true
+		for _, p := range ps {
+			if p*p > next {
+				break
+			}
+			if next%p == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			ps = append(ps, next)
+		}
+	}
+	return ps
+}
+
+// Opposition is a Sampler implementing opposition-based initialization: it
+// generates points by pairs, made of a point produced by Sampler and of its
+// opposite point, symmetric with respect to the center of the hypercube.
+//
+// Used to generate the initial population of an elitist evolution, the fitter
+// candidate of each pair tends to survive, which speeds up convergence.
+type Opposition struct {
+	// Sampler generates the first point of each pair. If nil, points are
+	// uniformly distributed.
+	Sampler Sampler
+
+	mu       sync.Mutex
+	opposite []float64
+}
+
+// Sample fills x with the coordinates of the next point.
+func (s *Opposition) Sample(x []float64, rng *rand.Rand) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.opposite != nil && len(s.opposite) == len(x) {
+		copy(x, s.opposite)
+		s.opposite = nil
+		return
+	}
+
+	if s.Sampler == nil {
+		Uniform{}.Sample(x, rng)
+	} else {
+		s.Sampler.Sample(x, rng)
+	}
+	s.opposite = make([]float64, len(x))
+	for i := range x {
+		s.opposite[i] = 1 - x[i]
+	}
+}
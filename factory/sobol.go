@@ -0,0 +1,104 @@
+package factory
+
+import (
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"sync"
+)
+
+// MaxSobolDim is the maximum number of dimensions supported by Sobol.
+const MaxSobolDim = len(sobolParams) + 1
+
+// sobolParams holds, for each dimension but the first, the degree s and the
+// coefficients a of the primitive polynomial, and the initial direction
+// numbers m, from the tables by S. Joe and F. Y. Kuo.
+var sobolParams = [...]struct {
+	s, a uint32
+	m    []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+	{5, 11, []uint32{1, 1, 5, 1, 1}},
+	{5, 13, []uint32{1, 1, 1, 3, 11}},
+	{5, 14, []uint32{1, 3, 5, 5, 31}},
+	{6, 1, []uint32{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint32{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint32{1, 3, 1, 13, 27, 49}},
+	{6, 19, []uint32{1, 1, 1, 15, 7, 5}},
+	{6, 22, []uint32{1, 3, 1, 15, 13, 25}},
+	{6, 25, []uint32{1, 1, 5, 5, 19, 61}},
+	{7, 1, []uint32{1, 3, 7, 11, 23, 15, 103}},
+	{7, 4, []uint32{1, 3, 7, 13, 13, 15, 69}},
+}
+
+// Sobol is a Sampler generating the points of the Sobol quasi-random sequence,
+// which covers the search space more evenly than uniformly distributed points.
+// The sequence is deterministic, rng is not used.
+//
+// Sobol supports up to MaxSobolDim dimensions, Sample panics beyond.
+type Sobol struct {
+	mu    sync.Mutex
+	index uint32
+	dirs  [][32]uint32 // direction numbers of each dimension
+	cur   []uint32
+}
+
+// Sample fills x with the coordinates of the next point of the sequence.
+func (s *Sobol) Sample(x []float64, _ *rand.Rand) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.dirs) != len(x) {
+		s.init(len(x))
+	}
+	s.next()
+	for i := range x {
+		x[i] = float64(s.cur[i]) / (1 << 32)
+	}
+}
+
+func (s *Sobol) init(d int) {
+	if d > MaxSobolDim {
+		panic(fmt.Sprintf("Sobol supports up to %d dimensions, got %d", MaxSobolDim, d))
+	}
+	s.index = 0
+	s.cur = make([]uint32, d)
+	s.dirs = make([][32]uint32, d)
+	for k := range s.dirs[0] {
+		s.dirs[0][k] = 1 << (31 - k)
+	}
+	for j := 1; j < d; j++ {
+		p := sobolParams[j-1]
+		v := &s.dirs[j]
+		for k := 0; k < 32; k++ {
+			if k < int(p.s) {
+				v[k] = p.m[k] << (31 - k)
+				continue
+			}
+			v[k] = v[k-int(p.s)] ^ (v[k-int(p.s)] >> p.s)
+			for i := 1; i < int(p.s); i++ {
+				if (p.a>>(p.s-1-uint32(i)))&1 != 0 {
+					v[k] ^= v[k-i]
+				}
+			}
+		}
+	}
+}
+
+// next advances to the next point of the sequence, using Gray code ordering.
+// The origin, the first point of the sequence, is skipped.
+func (s *Sobol) next() {
+	c := bits.TrailingZeros32(^s.index)
+	for j := range s.cur {
+		s.cur[j] ^= s.dirs[j][c]
+	}
+	s.index++
+}
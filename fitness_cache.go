@@ -1,6 +1,9 @@
 package evolve
 
-import "sync"
+import (
+	"sync"
+	"sync/atomic"
+)
 
 // FitnessCache provides caching for any Evaluator implementation. The results
 // of fitness evaluations are stored in a cache so that if the same candidate is
@@ -21,6 +24,9 @@ import "sync"
 // unless the fitness evaluator ignores the second parameter to the
 // Evaluator.Fitness method, caching must not be used.
 type FitnessCache[T any] struct {
+	// Number of cache hits and misses, accessed atomically. Kept first for
+	// 64-bit alignment on 32-bit platforms.
+	hits, misses uint64
 
 	// Wrapped is the fitness evaluator for which we want to provide caching.
 	Wrapped Evaluator[T]
@@ -38,13 +44,26 @@ func (c *FitnessCache[T]) Fitness(cand T, pop []T) float64 {
 	val, ok := c.cache.Load(cand)
 	if ok {
 		fitness = val.(float64)
+		atomic.AddUint64(&c.hits, 1)
 	} else {
 		fitness = c.Wrapped.Fitness(cand, pop)
 		c.cache.Store(cand, fitness)
+		atomic.AddUint64(&c.misses, 1)
 	}
 	return fitness
 }
 
+// HitRatio returns the ratio of fitness evaluations that have been served by
+// the cache, or 0 if no evaluations have been performed yet.
+func (c *FitnessCache[T]) HitRatio() float64 {
+	hits := atomic.LoadUint64(&c.hits)
+	misses := atomic.LoadUint64(&c.misses)
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
 // IsNatural specifies whether this evaluator generates 'natural' fitness
 // scores or not.
 func (c *FitnessCache[T]) IsNatural() bool { return c.Wrapped.IsNatural() }
@@ -57,3 +57,17 @@ func TestFitnessCacheNaturalness(t *testing.T) {
 		t.Errorf("fitness cache should not be natural if wrapped is not natural")
 	}
 }
+
+func TestFitnessCacheHitRatio(t *testing.T) {
+	eval := FitnessCache[int]{Wrapped: &incrEvaluator{natural: true}}
+	if got := eval.HitRatio(); got != 0 {
+		t.Errorf("hit ratio = %v, want 0", got)
+	}
+	eval.Fitness(101, nil)
+	eval.Fitness(101, nil)
+	eval.Fitness(101, nil)
+	eval.Fitness(202, nil)
+	if got := eval.HitRatio(); got != 0.5 {
+		t.Errorf("hit ratio = %v, want 0.5", got)
+	}
+}
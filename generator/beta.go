@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Beta generates values in [0, 1] following a beta distribution, whose shape
+// parameters alpha and beta are determined by Float generators.
+type Beta struct {
+	rng         *rand.Rand
+	alpha, beta Float
+}
+
+// NewBeta creates a generator of beta-distributed values. The shape
+// parameters alpha and beta must be strictly positive.
+//
+// The mean of this distribution is alpha/(alpha+beta).
+func NewBeta(alpha, beta Float, rng *rand.Rand) *Beta {
+	return &Beta{alpha: alpha, beta: beta, rng: rng}
+}
+
+// Next returns the next beta-distributed value.
+func (g *Beta) Next() float64 {
+	x := gamma(g.alpha.Next(), g.rng)
+	y := gamma(g.beta.Next(), g.rng)
+	return x / (x + y)
+}
+
+// gamma returns a gamma-distributed value of the given shape, and a scale of 1,
+// using the Marsaglia and Tsang method.
+func gamma(shape float64, rng *rand.Rand) float64 {
+	if shape < 1 {
+		// Boost the shape, then correct the result.
+		u := 1 - rng.Float64()
+		return gamma(shape+1, rng) * math.Pow(u, 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := rng.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rng.Float64()
+		if u < 1-0.0331*x*x*x*x {
+			return d * v
+		}
+		if math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/pkg/mt19937"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBeta(t *testing.T) {
+	tests := []struct{ alpha, beta float64 }{
+		{2, 5},
+		{5, 1},
+		{0.5, 0.5},
+		{3, 3},
+	}
+	for _, tt := range tests {
+		rng := rand.New(mt19937.New(99))
+		g := NewBeta(Const(tt.alpha), Const(tt.beta), rng)
+
+		const iterations = 10000
+		ds := evolve.NewDataset(iterations)
+		for i := 0; i < iterations; i++ {
+			val := g.Next()
+			if val < 0 || val > 1 {
+				t.Fatalf("generated value out of [0 1], got %v", val)
+			}
+			ds.AddValue(val)
+		}
+
+		a, b := tt.alpha, tt.beta
+		wantMean := a / (a + b)
+		wantStdDev := math.Sqrt(a * b / ((a + b) * (a + b) * (a + b + 1)))
+
+		const ε = 0.02
+		assert.InEpsilon(t, wantMean, ds.ArithmeticMean(), ε,
+			"alpha=%v beta=%v: observed mean is outside of acceptable range", a, b)
+		assert.InEpsilon(t, wantStdDev, ds.SampleStandardDeviation(), ε,
+			"alpha=%v beta=%v: observed standard deviation is outside of acceptable range", a, b)
+	}
+}
@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"math/rand"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Binomial generates binomially-distributed values, that is the number of
+// successes among n independent trials, each succeeding with probability p.
+type Binomial[I constraints.Integer] struct {
+	rng *rand.Rand
+	n   Generator[I]
+	p   Float
+}
+
+// NewBinomial creates a generator of binomially-distributed values, where the
+// number of trials and the probability of success are determined by
+// generators.
+func NewBinomial[I constraints.Integer](n Generator[I], p Float, rng *rand.Rand) *Binomial[I] {
+	return &Binomial[I]{n: n, p: p, rng: rng}
+}
+
+// Next returns the next binomially-distributed value.
+func (g *Binomial[I]) Next() I {
+	n, p := g.n.Next(), g.p.Next()
+
+	var k I
+	for i := I(0); i < n; i++ {
+		if g.rng.Float64() < p {
+			k++
+		}
+	}
+	return k
+}
@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve/pkg/mt19937"
+)
+
+func TestBinomial(t *testing.T) {
+	const n, p = 20, 0.3
+
+	rng := rand.New(mt19937.New(99))
+	g := NewBinomial[int](Const(n), Const(p), rng)
+	checkBinomialDistribution[int](t, g, n, p)
+}
+
+func TestBinomialDynamic(t *testing.T) {
+	rng := rand.New(mt19937.New(99))
+
+	gn := NewSwappable[uint](Const[uint](20))
+	gp := NewSwappable(Const(0.3))
+	g := NewBinomial[uint](gn, gp, rng)
+	checkBinomialDistribution[uint](t, g, 20, 0.3)
+
+	gn.Swap(Const[uint](50))
+	gp.Swap(Const(0.7))
+	checkBinomialDistribution[uint](t, g, 50, 0.7)
+}
@@ -0,0 +1,25 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Cauchy generates values following a Cauchy (or Lorentz) distribution, whose
+// location and scale are determined by Float generators. The Cauchy
+// distribution has heavy tails, and no defined mean nor variance.
+type Cauchy struct {
+	rng             *rand.Rand
+	location, scale Float
+}
+
+// NewCauchy creates a generator of Cauchy-distributed values, of the given
+// location (the median) and scale (half the interquartile range).
+func NewCauchy(location, scale Float, rng *rand.Rand) *Cauchy {
+	return &Cauchy{location: location, scale: scale, rng: rng}
+}
+
+// Next returns the next Cauchy-distributed value.
+func (g *Cauchy) Next() float64 {
+	return g.location.Next() + g.scale.Next()*math.Tan(math.Pi*(g.rng.Float64()-0.5))
+}
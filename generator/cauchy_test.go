@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/arl/evolve/pkg/mt19937"
+	"github.com/stretchr/testify/assert"
+)
+
+// quartiles returns the first, second and third quartiles of n values
+// generated by g.
+func quartiles(g Float, n int) (q1, q2, q3 float64) {
+	vals := make([]float64, n)
+	for i := range vals {
+		vals[i] = g.Next()
+	}
+	sort.Float64s(vals)
+	return vals[n/4], vals[n/2], vals[3*n/4]
+}
+
+func TestCauchy(t *testing.T) {
+	const location, scale float64 = 12, 3
+
+	rng := rand.New(mt19937.New(99))
+	g := NewCauchy(Const(location), Const(scale), rng)
+
+	// The Cauchy distribution has no mean, nor standard deviation, but its
+	// quartiles are location-scale, location and location+scale.
+	q1, q2, q3 := quartiles(g, 10000)
+
+	const ε = 0.02
+	assert.InEpsilon(t, location, q2, ε, "observed median is outside of acceptable range")
+	assert.InEpsilon(t, 2*scale, q3-q1, 0.05, "observed interquartile range is outside of acceptable range")
+}
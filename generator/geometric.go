@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Geometric generates geometrically-distributed values, that is the number of
+// failures before the first success, in a sequence of independent trials each
+// succeeding with probability p.
+type Geometric[I constraints.Integer] struct {
+	rng *rand.Rand
+	p   Float
+}
+
+// NewGeometric creates a generator of geometrically-distributed values, where
+// the probability of success, in (0, 1], is determined by a Float generator.
+//
+// The mean of this distribution is (1-p)/p and its variance is (1-p)/p².
+func NewGeometric[I constraints.Integer](p Float, rng *rand.Rand) *Geometric[I] {
+	return &Geometric[I]{p: p, rng: rng}
+}
+
+// Next returns the next geometrically-distributed value.
+func (g *Geometric[I]) Next() I {
+	p := g.p.Next()
+	if p >= 1 {
+		return 0
+	}
+	// 1-Float64() is in (0 1], so its logarithm is finite.
+	u := 1 - g.rng.Float64()
+	return I(math.Floor(math.Log(u) / math.Log1p(-p)))
+}
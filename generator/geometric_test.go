@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/pkg/mt19937"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeometric(t *testing.T) {
+	for _, p := range []float64{0.1, 0.25, 0.5} {
+		rng := rand.New(mt19937.New(99))
+		g := NewGeometric[int](Const(p), rng)
+
+		const iterations = 10000
+		ds := evolve.NewDataset(iterations)
+		for i := 0; i < iterations; i++ {
+			val := g.Next()
+			if val < 0 {
+				t.Fatalf("generated value must be non-negative, got %v", val)
+			}
+			ds.AddValue(float64(val))
+		}
+
+		const ε = 0.03
+		assert.InEpsilon(t, (1-p)/p, ds.ArithmeticMean(), ε,
+			"p=%v: observed mean is outside of acceptable range", p)
+		assert.InEpsilon(t, math.Sqrt(1-p)/p, ds.SampleStandardDeviation(), ε,
+			"p=%v: observed standard deviation is outside of acceptable range", p)
+	}
+}
+
+func TestGeometricCertainSuccess(t *testing.T) {
+	rng := rand.New(mt19937.New(99))
+	g := NewGeometric[uint8](Const(1.0), rng)
+	for i := 0; i < 100; i++ {
+		assert.Zero(t, g.Next())
+	}
+}
@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+)
+
+// LevyStable generates values following a symmetric Lévy alpha-stable
+// distribution, centered on 0, typically used to perform Lévy flights.
+//
+// The stability parameter alpha, in (0, 2], controls the heaviness of the
+// tails: alpha = 2 gives a Gaussian distribution of variance 2*scale², alpha =
+// 1 gives a Cauchy distribution, and lower values give heavier tails.
+type LevyStable struct {
+	rng          *rand.Rand
+	alpha, scale Float
+}
+
+// NewLevyStable creates a generator of symmetric alpha-stable distributed
+// values, with stability parameter alpha and scale determined by Float
+// generators.
+func NewLevyStable(alpha, scale Float, rng *rand.Rand) *LevyStable {
+	return &LevyStable{alpha: alpha, scale: scale, rng: rng}
+}
+
+// Next returns the next alpha-stable distributed value, using the
+// Chambers-Mallows-Stuck method.
+func (g *LevyStable) Next() float64 {
+	alpha := g.alpha.Next()
+	v := math.Pi * (g.rng.Float64() - 0.5)
+	w := g.rng.ExpFloat64()
+
+	var x float64
+	if alpha == 1 {
+		x = math.Tan(v)
+	} else {
+		x = math.Sin(alpha*v) / math.Pow(math.Cos(v), 1/alpha) *
+			math.Pow(math.Cos(v-alpha*v)/w, (1-alpha)/alpha)
+	}
+	return g.scale.Next() * x
+}
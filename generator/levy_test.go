@@ -0,0 +1,61 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/pkg/mt19937"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLevyStableGaussian(t *testing.T) {
+	const scale = 5
+
+	rng := rand.New(mt19937.New(99))
+	g := NewLevyStable(Const(2.0), Const(float64(scale)), rng)
+
+	const iterations = 10000
+	ds := evolve.NewDataset(iterations)
+	for i := 0; i < iterations; i++ {
+		ds.AddValue(g.Next())
+	}
+
+	// With alpha = 2, the distribution is normal, of variance 2*scale².
+	assert.InDelta(t, 0, ds.ArithmeticMean(), 0.2, "observed mean is outside of acceptable range")
+	assert.InEpsilon(t, math.Sqrt2*scale, ds.SampleStandardDeviation(), 0.02,
+		"observed standard deviation is outside of acceptable range")
+}
+
+func TestLevyStableCauchy(t *testing.T) {
+	const scale = 3
+
+	rng := rand.New(mt19937.New(99))
+	g := NewLevyStable(Const(1.0), Const(float64(scale)), rng)
+
+	// With alpha = 1, the distribution is a Cauchy distribution.
+	q1, q2, q3 := quartiles(g, 10000)
+	assert.InDelta(t, 0, q2, 0.1, "observed median is outside of acceptable range")
+	assert.InEpsilon(t, 2*scale, q3-q1, 0.05, "observed interquartile range is outside of acceptable range")
+}
+
+func TestLevyStableTails(t *testing.T) {
+	// Lower alpha values give heavier tails, compare the proportion of values
+	// far from the center.
+	far := func(alpha float64) int {
+		rng := rand.New(mt19937.New(99))
+		g := NewLevyStable(Const(alpha), Const(1.0), rng)
+
+		n := 0
+		for i := 0; i < 10000; i++ {
+			if math.Abs(g.Next()) > 10 {
+				n++
+			}
+		}
+		return n
+	}
+
+	assert.Less(t, far(1.8), far(1.5))
+	assert.Less(t, far(1.5), far(1.0))
+}
@@ -0,0 +1,25 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+)
+
+// LogNormal generates log-normally distributed values, that is values whose
+// logarithm is normally distributed. Generated values are always positive.
+type LogNormal struct {
+	rng       *rand.Rand
+	mu, sigma Float
+}
+
+// NewLogNormal creates a generator of log-normally distributed values, where
+// mu and sigma, the mean and standard deviation of the logarithm of the
+// generated values, are determined by Float generators.
+func NewLogNormal(mu, sigma Float, rng *rand.Rand) *LogNormal {
+	return &LogNormal{mu: mu, sigma: sigma, rng: rng}
+}
+
+// Next returns the next log-normally distributed value.
+func (g *LogNormal) Next() float64 {
+	return math.Exp(g.mu.Next() + g.sigma.Next()*g.rng.NormFloat64())
+}
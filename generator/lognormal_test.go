@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/pkg/mt19937"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogNormal(t *testing.T) {
+	const mu, sigma float64 = 1, 0.25
+
+	rng := rand.New(mt19937.New(99))
+	g := NewLogNormal(Const(mu), Const(sigma), rng)
+
+	const iterations = 10000
+	ds := evolve.NewDataset(iterations)
+	for i := 0; i < iterations; i++ {
+		val := g.Next()
+		if val <= 0 {
+			t.Fatalf("generated value must be positive, got %v", val)
+		}
+		ds.AddValue(val)
+	}
+
+	s2 := sigma * sigma
+	wantMean := math.Exp(mu + s2/2)
+	wantStdDev := math.Sqrt((math.Exp(s2) - 1) * math.Exp(2*mu+s2))
+	wantMedian := math.Exp(mu)
+
+	const ε = 0.02
+	assert.InEpsilon(t, wantMean, ds.ArithmeticMean(), ε, "observed mean is outside of acceptable range")
+	assert.InEpsilon(t, wantMedian, ds.Median(), ε, "observed median is outside of acceptable range")
+	assert.InEpsilon(t, wantStdDev, ds.SampleStandardDeviation(), ε, "observed standard deviation is outside of acceptable range")
+}
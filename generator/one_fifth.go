@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"sync"
+
+	"github.com/arl/evolve"
+)
+
+// OneFifth is a feedback-driven generator implementing Rechenberg's 1/5th
+// success rule, typically used to adapt mutation step sizes.
+//
+// It must be registered as an observer of the evolution engine. A generation
+// is deemed successful when its best fitness improves on the best fitness seen
+// so far. Every Window generations, if more than 1/5th of them have been
+// successful, the generated value is divided by Factor in order to explore
+// further, while if less than 1/5th of them have been successful, it's
+// multiplied by Factor in order to exploit the neighbourhood.
+//
+// OneFifth is safe for concurrent use.
+type OneFifth[T any] struct {
+	// Factor is the adaptation factor, in (0, 1). If 0, it defaults to 0.817.
+	Factor float64
+
+	// Window is the number of generations between 2 adaptations. If 0, it
+	// defaults to 10.
+	Window int
+
+	// Min and Max bound the generated value, if Max is greater than 0.
+	Min, Max float64
+
+	mu        sync.Mutex
+	value     float64
+	best      float64
+	started   bool
+	gens      int
+	successes int
+}
+
+// NewOneFifth returns a OneFifth generator, initially generating initial.
+func NewOneFifth[T any](initial float64) *OneFifth[T] {
+	return &OneFifth[T]{value: initial}
+}
+
+// Next returns the current value.
+func (g *OneFifth[T]) Next() float64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.value
+}
+
+// Observe records the success of the last generation, and adapts the
+// generated value at the end of each window.
+func (g *OneFifth[T]) Observe(stats *evolve.PopulationStats[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if !g.started {
+		g.best = stats.BestFitness
+		g.started = true
+		return
+	}
+
+	improved := stats.BestFitness > g.best
+	if !stats.Natural {
+		improved = stats.BestFitness < g.best
+	}
+	if improved {
+		g.best = stats.BestFitness
+		g.successes++
+	}
+	g.gens++
+
+	window := g.Window
+	if window == 0 {
+		window = 10
+	}
+	if g.gens < window {
+		return
+	}
+
+	factor := g.Factor
+	if factor == 0 {
+		factor = 0.817
+	}
+	ratio := float64(g.successes) / float64(g.gens)
+	switch {
+	case ratio > 0.2:
+		g.value /= factor
+	case ratio < 0.2:
+		g.value *= factor
+	}
+	if g.Max > 0 {
+		if g.value > g.Max {
+			g.value = g.Max
+		}
+		if g.value < g.Min {
+			g.value = g.Min
+		}
+	}
+	g.gens, g.successes = 0, 0
+}
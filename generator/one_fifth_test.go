@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/arl/evolve"
+)
+
+func TestOneFifth(t *testing.T) {
+	g := NewOneFifth[int](1)
+	g.Window = 5
+	g.Factor = 0.5
+
+	stats := &evolve.PopulationStats[int]{Natural: true}
+	observe := func(fitness ...float64) {
+		for _, f := range fitness {
+			stats.BestFitness = f
+			g.Observe(stats)
+		}
+	}
+
+	// Initial generation.
+	observe(10)
+
+	// 2 successes out of 5: more than 1/5th, the value increases.
+	observe(11, 11, 12, 12, 12)
+	if got := g.Next(); got != 2 {
+		t.Errorf("after a successful window, got %v, want 2", got)
+	}
+
+	// No success: the value decreases.
+	observe(12, 12, 12, 12, 12)
+	if got := g.Next(); got != 1 {
+		t.Errorf("after an unsuccessful window, got %v, want 1", got)
+	}
+
+	// Exactly 1/5th: unchanged.
+	observe(13, 13, 13, 13, 13)
+	if got := g.Next(); got != 1 {
+		t.Errorf("after a window with 1/5th of success, got %v, want 1", got)
+	}
+
+	// Bounded value.
+	g.Min, g.Max = 0.8, 1.5
+	observe(14, 15, 16, 17, 18)
+	if got := g.Next(); got != 1.5 {
+		t.Errorf("got %v, want the value to be bounded to 1.5", got)
+	}
+	observe(18, 18, 18, 18, 18)
+	observe(18, 18, 18, 18, 18)
+	if got := g.Next(); got != 0.8 {
+		t.Errorf("got %v, want the value to be bounded to 0.8", got)
+	}
+
+	// With non-natural fitness, lower is better.
+	g = NewOneFifth[int](1)
+	g.Window = 2
+	g.Factor = 0.5
+	stats.Natural = false
+	observe(10, 9, 8)
+	if got := g.Next(); got != 2 {
+		t.Errorf("with non-natural fitness, got %v, want 2", got)
+	}
+}
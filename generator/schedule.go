@@ -0,0 +1,134 @@
+package generator
+
+import (
+	"math"
+	"sort"
+	"sync/atomic"
+)
+
+// A ClockUnit is the unit in which a Clock measures time.
+type ClockUnit int
+
+const (
+	// Generations measures time in generations.
+	Generations ClockUnit = iota
+
+	// Evaluations measures time in fitness evaluations.
+	Evaluations
+)
+
+// A Clock measures the progress of an evolution, driving schedules. It's
+// advanced by the evolution engine (see engine.Engine.Clock), or manually with
+// Set.
+//
+// Clock is safe for concurrent use.
+type Clock struct {
+	// Unit is the unit in which the clock measures time.
+	Unit ClockUnit
+
+	now int64
+}
+
+// Now returns the current time.
+func (c *Clock) Now() int {
+	return int(atomic.LoadInt64(&c.now))
+}
+
+// Set sets the current time.
+func (c *Clock) Set(t int) {
+	atomic.StoreInt64(&c.now, int64(t))
+}
+
+// Tick sets the current time to the given generation index or to the given
+// number of fitness evaluations, depending on the clock unit.
+func (c *Clock) Tick(generation, evaluations int) {
+	if c.Unit == Evaluations {
+		c.Set(evaluations)
+		return
+	}
+	c.Set(generation)
+}
+
+type schedule struct {
+	clock *Clock
+	f     func(t float64) float64
+}
+
+func (s schedule) Next() float64 { return s.f(float64(s.clock.Now())) }
+
+// NewLinearSchedule returns a generator whose value varies linearly with the
+// time measured by clock, from from at time 0 to to at time duration, and
+// remains at to afterwards. If duration is not positive, the value is always
+// to.
+func NewLinearSchedule(clock *Clock, from, to float64, duration int) Float {
+	return schedule{clock: clock, f: func(t float64) float64 {
+		if duration <= 0 || t >= float64(duration) {
+			return to
+		}
+		return from + (to-from)*t/float64(duration)
+	}}
+}
+
+// NewExponentialSchedule returns a generator whose value decays (or grows)
+// exponentially with the time t measured by clock, that is initial*rate^t.
+func NewExponentialSchedule(clock *Clock, initial, rate float64) Float {
+	return schedule{clock: clock, f: func(t float64) float64 {
+		return initial * math.Pow(rate, t)
+	}}
+}
+
+// NewStepSchedule returns a generator whose value starts at initial and is
+// multiplied by factor every step units of the time measured by clock. step
+// must be positive.
+func NewStepSchedule(clock *Clock, initial, factor float64, step int) Float {
+	if step <= 0 {
+		panic("step schedule requires a positive step")
+	}
+	return schedule{clock: clock, f: func(t float64) float64 {
+		return initial * math.Pow(factor, math.Floor(t/float64(step)))
+	}}
+}
+
+// NewCosineSchedule returns a generator whose value follows a half cosine
+// wave, as in cosine annealing, from max at time 0 to min at time duration,
+// and remains at min afterwards. If duration is not positive, the value is
+// always min.
+func NewCosineSchedule(clock *Clock, max, min float64, duration int) Float {
+	return schedule{clock: clock, f: func(t float64) float64 {
+		if duration <= 0 || t >= float64(duration) {
+			return min
+		}
+		return min + 0.5*(max-min)*(1+math.Cos(math.Pi*t/float64(duration)))
+	}}
+}
+
+// A SchedulePoint is a point of a piecewise schedule, defining the value of
+// the schedule at a given time.
+type SchedulePoint struct {
+	Time  int
+	Value float64
+}
+
+// NewPiecewiseSchedule returns a generator whose value is linearly
+// interpolated between the given points, according to the time measured by
+// clock. Before the first point, and after the last one, the value remains
+// constant. At least one point must be provided.
+func NewPiecewiseSchedule(clock *Clock, points ...SchedulePoint) Float {
+	if len(points) == 0 {
+		panic("piecewise schedule requires at least one point")
+	}
+	pts := append([]SchedulePoint(nil), points...)
+	sort.Slice(pts, func(i, j int) bool { return pts[i].Time < pts[j].Time })
+
+	return schedule{clock: clock, f: func(t float64) float64 {
+		i := sort.Search(len(pts), func(i int) bool { return float64(pts[i].Time) > t })
+		switch i {
+		case 0:
+			return pts[0].Value
+		case len(pts):
+			return pts[len(pts)-1].Value
+		}
+		p0, p1 := pts[i-1], pts[i]
+		return p0.Value + (p1.Value-p0.Value)*(t-float64(p0.Time))/float64(p1.Time-p0.Time)
+	}}
+}
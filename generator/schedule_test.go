@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchedules(t *testing.T) {
+	clock := &Clock{}
+
+	tests := []struct {
+		name  string
+		sched Float
+		times []int
+		want  []float64
+	}{
+		{
+			name:  "linear",
+			sched: NewLinearSchedule(clock, 1, 0, 10),
+			times: []int{0, 5, 10, 20},
+			want:  []float64{1, 0.5, 0, 0},
+		},
+		{
+			name:  "linear zero duration",
+			sched: NewLinearSchedule(clock, 1, 0, 0),
+			times: []int{0, 5},
+			want:  []float64{0, 0},
+		},
+		{
+			name:  "exponential",
+			sched: NewExponentialSchedule(clock, 8, 0.5),
+			times: []int{0, 1, 3},
+			want:  []float64{8, 4, 1},
+		},
+		{
+			name:  "step",
+			sched: NewStepSchedule(clock, 1, 0.1, 10),
+			times: []int{0, 9, 10, 25},
+			want:  []float64{1, 1, 0.1, 0.01},
+		},
+		{
+			name:  "cosine",
+			sched: NewCosineSchedule(clock, 1, 0, 10),
+			times: []int{0, 5, 10, 11},
+			want:  []float64{1, 0.5, 0, 0},
+		},
+		{
+			name:  "cosine zero duration",
+			sched: NewCosineSchedule(clock, 1, 0, 0),
+			times: []int{0, 5},
+			want:  []float64{0, 0},
+		},
+		{
+			name: "piecewise",
+			sched: NewPiecewiseSchedule(clock,
+				SchedulePoint{Time: 10, Value: 1},
+				SchedulePoint{Time: 20, Value: 0},
+				SchedulePoint{Time: 30, Value: 0.5},
+			),
+			times: []int{0, 10, 15, 20, 25, 30, 40},
+			want:  []float64{1, 1, 0.5, 0, 0.25, 0.5, 0.5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, now := range tt.times {
+				clock.Set(now)
+				if got := tt.sched.Next(); math.Abs(got-tt.want[i]) > 1e-12 {
+					t.Errorf("at time %d: got %v, want %v", now, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestStepSchedulePanics(t *testing.T) {
+	assert.Panics(t, func() { NewStepSchedule(&Clock{}, 1, 0.5, 0) }, "zero step")
+	assert.Panics(t, func() { NewStepSchedule(&Clock{}, 1, 0.5, -1) }, "negative step")
+}
+
+func TestClockTick(t *testing.T) {
+	gens := &Clock{Unit: Generations}
+	evals := &Clock{Unit: Evaluations}
+	gens.Tick(3, 300)
+	evals.Tick(3, 300)
+	if gens.Now() != 3 {
+		t.Errorf("generations clock = %d, want 3", gens.Now())
+	}
+	if evals.Now() != 300 {
+		t.Errorf("evaluations clock = %d, want 300", evals.Now())
+	}
+}
@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+)
+
+// TruncatedGaussian generates normally-distributed values, restricted to the
+// [min, max] range. Mean and standard deviation, of the distribution before
+// truncation, are determined by Float generators.
+type TruncatedGaussian struct {
+	rng          *rand.Rand
+	mean, stddev Float
+	min, max     float64
+}
+
+// NewTruncatedGaussian creates a generator of normally-distributed values,
+// truncated to the [min, max] range. NewTruncatedGaussian panics if max < min.
+func NewTruncatedGaussian(mean, stddev Float, min, max float64, rng *rand.Rand) *TruncatedGaussian {
+	if max < min {
+		panic("must have min <= max")
+	}
+	return &TruncatedGaussian{mean: mean, stddev: stddev, min: min, max: max, rng: rng}
+}
+
+// maxRejections is the number of rejected samples after which a truncated
+// gaussian gives up and clamps the last sample into the range.
+const maxRejections = 1000
+
+// Next returns the next normally-distributed value within the range.
+func (g *TruncatedGaussian) Next() float64 {
+	mean, stddev := g.mean.Next(), g.stddev.Next()
+
+	var x float64
+	for i := 0; i < maxRejections; i++ {
+		x = g.rng.NormFloat64()*stddev + mean
+		if x >= g.min && x <= g.max {
+			return x
+		}
+	}
+	// The range is too far in the tails for rejection sampling.
+	return math.Max(g.min, math.Min(g.max, x))
+}
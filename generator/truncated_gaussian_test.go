@@ -0,0 +1,62 @@
+package generator
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/pkg/mt19937"
+	"github.com/stretchr/testify/assert"
+)
+
+func checkTruncatedGaussian(t *testing.T, g *TruncatedGaussian, min, max, wantMean float64) *evolve.Dataset {
+	t.Helper()
+
+	const iterations = 10000
+	ds := evolve.NewDataset(iterations)
+	for i := 0; i < iterations; i++ {
+		val := g.Next()
+		if val < min || val > max {
+			t.Fatalf("generated value out of [%v %v], got %v", min, max, val)
+		}
+		ds.AddValue(val)
+	}
+
+	assert.InEpsilon(t, wantMean, ds.ArithmeticMean(), 0.02, "observed mean is outside of acceptable range")
+	return ds
+}
+
+func TestTruncatedGaussian(t *testing.T) {
+	t.Run("symmetric", func(t *testing.T) {
+		const mean, stddev float64 = 50, 10
+
+		rng := rand.New(mt19937.New(99))
+		g := NewTruncatedGaussian(Const(mean), Const(stddev), 40, 60, rng)
+		ds := checkTruncatedGaussian(t, g, 40, 60, mean)
+
+		// Truncation reduces the standard deviation.
+		assert.Less(t, ds.SampleStandardDeviation(), stddev)
+	})
+
+	t.Run("half-normal", func(t *testing.T) {
+		const mean, stddev float64 = 10, 4
+
+		rng := rand.New(mt19937.New(99))
+		g := NewTruncatedGaussian(Const(mean), Const(stddev), mean, math.Inf(1), rng)
+		checkTruncatedGaussian(t, g, mean, math.Inf(1), mean+stddev*math.Sqrt(2/math.Pi))
+	})
+
+	t.Run("far tail", func(t *testing.T) {
+		// The range is so far in the tail that rejection sampling fails, values
+		// are clamped.
+		rng := rand.New(mt19937.New(99))
+		g := NewTruncatedGaussian(Const(0.0), Const(1.0), 100, 101, rng)
+		checkTruncatedGaussian(t, g, 100, 101, 100)
+	})
+}
+
+func TestTruncatedGaussianPanics(t *testing.T) {
+	rng := rand.New(mt19937.New(99))
+	assert.Panics(t, func() { NewTruncatedGaussian(Const(0.0), Const(1.0), 1, 0, rng) })
+}
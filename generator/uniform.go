@@ -8,17 +8,12 @@ import (
 )
 
 // Uniform returns a generator of random numbers which are uniformly distributed
-// in the [min max) range. The range must be made of positive numbers only. In
-// other words if T is a floating point number type, then min must be positive.
-// Uniform panics if max <= min.
-func Uniform[T constraints.Unsigned | constraints.Float](min, max T, rng *rand.Rand) Generator[T] {
-	diff := max - min
-	if diff <= 0 {
+// in the [min max) range. Uniform panics if max <= min.
+func Uniform[T constraints.Integer | constraints.Float](min, max T, rng *rand.Rand) Generator[T] {
+	if max <= min {
 		panic("must have min < max")
 	}
-	if min < 0 {
-		panic("must have min positive")
-	}
+	diff := max - min
 
 	// TODO(generics) check status of generic type switches proposal
 	// https://github.com/golang/go/issues/45380
@@ -29,9 +24,10 @@ func Uniform[T constraints.Unsigned | constraints.Float](min, max T, rng *rand.R
 		reflect.Int16, reflect.Uint16,
 		reflect.Int32, reflect.Uint32,
 		reflect.Int64, reflect.Uint64:
-		idiff := int64(diff)
+		// Compute the range width in int64, it may overflow T for signed types.
+		imin, idiff := int64(min), int64(max)-int64(min)
 		return uniform[T](func() T {
-			return min + T(rng.Int63n(idiff))
+			return T(imin + rng.Int63n(idiff))
 		})
 	case reflect.Float32:
 		f32diff := float32(diff)
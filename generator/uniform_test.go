@@ -5,7 +5,9 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/arl/evolve"
 	"github.com/arl/evolve/pkg/mt19937"
+	"github.com/stretchr/testify/assert"
 	"golang.org/x/exp/constraints"
 )
 
@@ -33,3 +35,33 @@ func testUniform[T constraints.Unsigned | constraints.Float](min, max T, t *test
 
 	checkUniformDistribution(t, g, float64(mean), stddev)
 }
+
+func TestUniformSigned(t *testing.T) {
+	testUniformSigned[int](-50, 150, t)
+	testUniformSigned[int8](-100, 100, t)
+	testUniformSigned[int64](-1000, -10, t)
+	testUniformSigned[float64](-30, 70, t)
+}
+
+func testUniformSigned[T constraints.Signed | constraints.Float](min, max T, t *testing.T) {
+	rng := rand.New(mt19937.New(0))
+	g := Uniform(min, max, rng)
+
+	const iterations = 10000
+	ds := evolve.NewDataset(iterations)
+	for i := 0; i < iterations; i++ {
+		val := g.Next()
+		if val < min || val >= max {
+			t.Fatalf("generated value out of range [%v %v), got %v", min, max, val)
+		}
+		ds.AddValue(float64(val))
+	}
+
+	// Compare the offset to min, since the mean of a signed range may be 0.
+	diff := float64(max) - float64(min)
+	const ε = 0.02
+	assert.InEpsilon(t, diff/2, ds.ArithmeticMean()-float64(min), ε,
+		"observed mean is outside of acceptable range")
+	assert.InEpsilon(t, diff/math.Sqrt(12), ds.SampleStandardDeviation(), ε,
+		"observed standard deviation is outside of acceptable range")
+}
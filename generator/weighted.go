@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"math/rand"
+	"sort"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Weighted generates values randomly chosen among a discrete set, each value
+// having a probability to be chosen proportional to its weight.
+type Weighted[T constraints.Integer | constraints.Float] struct {
+	rng    *rand.Rand
+	values []T
+	cumul  []float64
+}
+
+// NewWeighted creates a generator choosing among values, according to
+// weights. NewWeighted panics if values and weights don't have the same
+// length, if they're empty, if a weight is negative or if all weights are 0.
+func NewWeighted[T constraints.Integer | constraints.Float](values []T, weights []float64, rng *rand.Rand) *Weighted[T] {
+	if len(values) != len(weights) {
+		panic("values and weights must have the same length")
+	}
+	cumul := make([]float64, len(weights))
+	var sum float64
+	for i, w := range weights {
+		if w < 0 {
+			panic("weights must be non-negative")
+		}
+		sum += w
+		cumul[i] = sum
+	}
+	if sum == 0 {
+		panic("weights sum must be positive")
+	}
+
+	return &Weighted[T]{
+		rng:    rng,
+		values: append([]T(nil), values...),
+		cumul:  cumul,
+	}
+}
+
+// Next returns the next chosen value.
+func (g *Weighted[T]) Next() T {
+	r := g.rng.Float64() * g.cumul[len(g.cumul)-1]
+	i := sort.Search(len(g.cumul), func(i int) bool { return g.cumul[i] > r })
+	return g.values[i]
+}
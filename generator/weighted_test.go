@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve/pkg/mt19937"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeighted(t *testing.T) {
+	values := []int{-1, 0, 5, 7}
+	weights := []float64{1, 0, 3, 6}
+
+	rng := rand.New(mt19937.New(99))
+	g := NewWeighted(values, weights, rng)
+
+	const iterations = 10000
+	counts := make(map[int]int)
+	for i := 0; i < iterations; i++ {
+		counts[g.Next()]++
+	}
+
+	assert.Zero(t, counts[0], "value with a zero weight should never be chosen")
+	const ε = 0.05
+	assert.InEpsilon(t, 0.1, float64(counts[-1])/iterations, ε)
+	assert.InEpsilon(t, 0.3, float64(counts[5])/iterations, ε)
+	assert.InEpsilon(t, 0.6, float64(counts[7])/iterations, ε)
+}
+
+func TestWeightedPanics(t *testing.T) {
+	rng := rand.New(mt19937.New(99))
+
+	assert.Panics(t, func() { NewWeighted([]int{1, 2}, []float64{1}, rng) }, "length mismatch")
+	assert.Panics(t, func() { NewWeighted([]int{}, []float64{}, rng) }, "empty")
+	assert.Panics(t, func() { NewWeighted([]int{1, 2}, []float64{1, -1}, rng) }, "negative weight")
+	assert.Panics(t, func() { NewWeighted([]float64{1, 2}, []float64{0, 0}, rng) }, "zero weights")
+}
@@ -6,6 +6,7 @@ require (
 	github.com/arl/bitstring v0.1.0
 	github.com/stretchr/testify v1.7.0
 	golang.org/x/exp v0.0.0-20220203164150-d4f80a91470e
+	golang.org/x/term v0.10.0
 )
 
 require (
@@ -19,7 +20,7 @@ require (
 	github.com/gorilla/websocket v1.5.0 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/image v0.0.0-20220722155232-062f8c9fd539 // indirect
-	golang.org/x/sys v0.0.0-20220825204002-c680a09ffe64 // indirect
+	golang.org/x/sys v0.10.0 // indirect
 	golang.org/x/text v0.3.7 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
 )
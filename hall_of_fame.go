@@ -0,0 +1,116 @@
+package evolve
+
+import "reflect"
+
+// A HallOfFame keeps track of the best distinct individuals ever seen during
+// an evolution.
+//
+// Since the best individuals of a generation are not guaranteed to survive to
+// the next one, unless some form of elitism is used, the best individual ever
+// seen can be lost. The hall of fame keeps a record of the Size fittest
+// individuals, across all generations.
+type HallOfFame[T any] struct {
+	// Size is the maximum number of individuals kept in the hall of fame.
+	Size int
+
+	// Key returns a value identifying a candidate, used to distinguish
+	// candidates from each other. Candidates having equal keys (as per ==) are
+	// considered equal, and the hall of fame only keeps one of them. The
+	// returned value must be comparable. If Key is nil, candidates are
+	// compared with reflect.DeepEqual.
+	Key func(T) any
+
+	// Elites is the number of hall of fame members that are re-injected into
+	// each new generation, replacing the least fit candidates. It only applies
+	// when the hall of fame is used by an evolution engine.
+	Elites int
+
+	pop  Population[T]
+	keys []any
+}
+
+// Update considers all the candidates of pop for entering the hall of fame.
+// natural indicates whether fitness scores are natural or not.
+func (h *HallOfFame[T]) Update(pop *Population[T], natural bool) {
+	for i := range pop.Candidates {
+		h.add(pop.Candidates[i], pop.Fitness[i], natural)
+	}
+}
+
+// add adds cand to the hall of fame if it deserves it.
+func (h *HallOfFame[T]) add(cand T, fitness float64, natural bool) {
+	better := func(a, b float64) bool {
+		if natural {
+			return a > b
+		}
+		return a < b
+	}
+
+	n := h.pop.Len()
+	if n >= h.Size && (n == 0 || !better(fitness, h.pop.Fitness[n-1])) {
+		return
+	}
+
+	var key any
+	if h.Key != nil {
+		key = h.Key(cand)
+	}
+	for i := range h.keys {
+		if h.Key != nil && h.keys[i] != key || h.Key == nil && !reflect.DeepEqual(h.pop.Candidates[i], cand) {
+			continue
+		}
+		if !better(fitness, h.pop.Fitness[i]) {
+			return
+		}
+		// Remove the equal candidate, it'll be replaced by cand.
+		h.remove(i)
+		break
+	}
+
+	// Find insertion index, keeping the hall of fame sorted, fittest first.
+	idx := 0
+	for idx < h.pop.Len() && !better(fitness, h.pop.Fitness[idx]) {
+		idx++
+	}
+
+	var zero T
+	h.pop.Candidates = append(h.pop.Candidates, zero)
+	h.pop.Fitness = append(h.pop.Fitness, 0)
+	h.keys = append(h.keys, nil)
+	copy(h.pop.Candidates[idx+1:], h.pop.Candidates[idx:])
+	copy(h.pop.Fitness[idx+1:], h.pop.Fitness[idx:])
+	copy(h.keys[idx+1:], h.keys[idx:])
+	h.pop.Candidates[idx] = cand
+	h.pop.Fitness[idx] = fitness
+	h.keys[idx] = key
+
+	if h.pop.Len() > h.Size {
+		h.remove(h.pop.Len() - 1)
+	}
+}
+
+// remove removes the member at index i.
+func (h *HallOfFame[T]) remove(i int) {
+	h.pop.Candidates = append(h.pop.Candidates[:i], h.pop.Candidates[i+1:]...)
+	h.pop.Fitness = append(h.pop.Fitness[:i], h.pop.Fitness[i+1:]...)
+	h.keys = append(h.keys[:i], h.keys[i+1:]...)
+}
+
+// Len returns the number of individuals in the hall of fame.
+func (h *HallOfFame[T]) Len() int { return h.pop.Len() }
+
+// Population returns a copy of the hall of fame members and their fitness,
+// sorted from the fittest to the least fit.
+func (h *HallOfFame[T]) Population() *Population[T] {
+	pop := NewPopulation[T](h.pop.Len())
+	copy(pop.Candidates, h.pop.Candidates)
+	copy(pop.Fitness, h.pop.Fitness)
+	return pop
+}
+
+// Clear removes all members from the hall of fame.
+func (h *HallOfFame[T]) Clear() {
+	h.pop.Candidates = h.pop.Candidates[:0]
+	h.pop.Fitness = h.pop.Fitness[:0]
+	h.keys = h.keys[:0]
+}
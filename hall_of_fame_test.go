@@ -0,0 +1,80 @@
+package evolve
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newTestPopulation(cands []string, fitness []float64) *Population[string] {
+	pop := NewPopulation[string](len(cands))
+	copy(pop.Candidates, cands)
+	copy(pop.Fitness, fitness)
+	return pop
+}
+
+func TestHallOfFame(t *testing.T) {
+	t.Run("natural", func(t *testing.T) {
+		hof := HallOfFame[string]{Size: 3}
+		hof.Update(newTestPopulation([]string{"a", "b", "c", "d"}, []float64{1, 4, 2, 3}), true)
+		hof.Update(newTestPopulation([]string{"e", "f"}, []float64{0, 5}), true)
+
+		got := hof.Population()
+		if want := []string{"f", "b", "d"}; !reflect.DeepEqual(got.Candidates, want) {
+			t.Errorf("candidates = %v, want %v", got.Candidates, want)
+		}
+		if want := []float64{5, 4, 3}; !reflect.DeepEqual(got.Fitness, want) {
+			t.Errorf("fitness = %v, want %v", got.Fitness, want)
+		}
+	})
+
+	t.Run("non-natural", func(t *testing.T) {
+		hof := HallOfFame[string]{Size: 2}
+		hof.Update(newTestPopulation([]string{"a", "b", "c"}, []float64{1, 4, 2}), false)
+
+		got := hof.Population()
+		if want := []string{"a", "c"}; !reflect.DeepEqual(got.Candidates, want) {
+			t.Errorf("candidates = %v, want %v", got.Candidates, want)
+		}
+	})
+
+	t.Run("unique", func(t *testing.T) {
+		hof := HallOfFame[string]{
+			Size: 3,
+			Key:  func(s string) any { return s },
+		}
+		hof.Update(newTestPopulation([]string{"a", "a", "b", "a", "c"}, []float64{5, 5, 4, 6, 1}), true)
+
+		got := hof.Population()
+		if want := []string{"a", "b", "c"}; !reflect.DeepEqual(got.Candidates, want) {
+			t.Errorf("candidates = %v, want %v", got.Candidates, want)
+		}
+		if want := []float64{6, 4, 1}; !reflect.DeepEqual(got.Fitness, want) {
+			t.Errorf("fitness = %v, want %v", got.Fitness, want)
+		}
+
+		hof.Clear()
+		if hof.Len() != 0 {
+			t.Errorf("after Clear, Len() = %d, want 0", hof.Len())
+		}
+	})
+
+	t.Run("surviving elite", func(t *testing.T) {
+		// Without Key, the same elite surviving several generations is only
+		// kept once.
+		hof := HallOfFame[[]int]{Size: 3}
+		elite := []int{1, 2}
+		for gen := 0; gen < 4; gen++ {
+			pop := NewPopulation[[]int](2)
+			pop.Candidates[0], pop.Fitness[0] = elite, 10
+			pop.Candidates[1], pop.Fitness[1] = []int{gen}, float64(gen)
+			hof.Update(pop, true)
+		}
+		// An equal copy of the elite isn't added either.
+		hof.Update(&Population[[]int]{Candidates: [][]int{{1, 2}}, Fitness: []float64{10}}, true)
+
+		got := hof.Population()
+		if want := [][]int{{1, 2}, {3}, {2}}; !reflect.DeepEqual(got.Candidates, want) {
+			t.Errorf("candidates = %v, want %v", got.Candidates, want)
+		}
+	})
+}
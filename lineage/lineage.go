@@ -0,0 +1,264 @@
+// Package lineage provides genealogy tracking of the individuals of an
+// evolution, in order to know where they come from.
+//
+// Lineage tracking works on individuals of type *Individual[T], wrapping the
+// genomes of type T alongside a unique ID. The Factory, Evaluator, Mater and
+// Mutater types adapt the factory, evaluator and operators of T, so that they
+// work with individuals and record, in a Tree, the birth of each individual:
+// its parents, the operators that produced it, the generation it was born in
+// and its fitness.
+//
+// The ancestry of any individual can then be exported to Graphviz DOT or JSON,
+// to analyse which operators contribute to improvements.
+package lineage
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/arl/evolve"
+)
+
+// An Individual is a genome whose lineage is tracked.
+type Individual[T any] struct {
+	// ID uniquely identifies the individual in its Tree.
+	ID uint64
+
+	// Genome is the candidate solution.
+	Genome T
+}
+
+// A Record describes the birth of an individual.
+type Record struct {
+	// ID is the individual ID.
+	ID uint64 `json:"id"`
+
+	// Parents holds the IDs of the individual parents, empty for individuals
+	// created by a factory.
+	Parents []uint64 `json:"parents,omitempty"`
+
+	// Operators holds the names of the operators that produced the
+	// individual, in the order they have been applied.
+	Operators []string `json:"operators,omitempty"`
+
+	// Generation is the generation in which the individual was born.
+	Generation int `json:"generation"`
+
+	// Fitness is the individual fitness, only valid if Evaluated is true.
+	Fitness float64 `json:"fitness"`
+
+	// Evaluated indicates whether the individual has been evaluated.
+	Evaluated bool `json:"evaluated"`
+}
+
+// A Tree records the lineage of all the individuals of an evolution.
+//
+// A Tree must be added to the engine observers, so as to know the current
+// generation. Individuals produced by a sequence of operators in the same
+// generation, for example crossover followed by mutation in an
+// operator.Pipeline, are recorded as a single birth: intermediate offspring,
+// which are never evaluated, are forgotten at the start of the next generation.
+//
+// The records of all individuals are kept until Prune is called. A Tree is safe
+// for concurrent use.
+type Tree[T any] struct {
+	mu      sync.Mutex
+	records map[uint64]*Record
+	lastID  uint64
+	gen     int
+}
+
+// NewTree returns a new, empty, Tree.
+func NewTree[T any]() *Tree[T] {
+	return &Tree[T]{records: make(map[uint64]*Record)}
+}
+
+// Observe implements engine.Observer. It does nothing, population statistics
+// are not needed to track lineage.
+func (t *Tree[T]) Observe(*evolve.PopulationStats[*Individual[T]]) {}
+
+// OnGenerationStart records the current generation, and forgets intermediate
+// offspring of the previous generations.
+func (t *Tree[T]) OnGenerationStart(gen int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.gen = gen
+	for id, r := range t.records {
+		if !r.Evaluated && r.Generation < gen {
+			delete(t.records, id)
+		}
+	}
+}
+
+// add records the birth of a new individual having the given genome, produced
+// by op from parents. Parents that are intermediate offspring are replaced by
+// their own parents, and their operators are inherited.
+func (t *Tree[T]) add(genome T, op string, parents ...*Individual[T]) *Individual[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lastID++
+	r := &Record{ID: t.lastID, Generation: t.gen}
+	for _, p := range parents {
+		pr, ok := t.records[p.ID]
+		if ok && !pr.Evaluated && pr.Generation == t.gen && len(pr.Parents) != 0 {
+			r.Parents = appendUnique(r.Parents, pr.Parents...)
+			for _, name := range pr.Operators {
+				if !contains(r.Operators, name) {
+					r.Operators = append(r.Operators, name)
+				}
+			}
+			continue
+		}
+		r.Parents = appendUnique(r.Parents, p.ID)
+	}
+	if op != "" && !contains(r.Operators, op) {
+		r.Operators = append(r.Operators, op)
+	}
+	t.records[r.ID] = r
+	return &Individual[T]{ID: r.ID, Genome: genome}
+}
+
+func (t *Tree[T]) setFitness(id uint64, fitness float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if r, ok := t.records[id]; ok {
+		r.Fitness = fitness
+		r.Evaluated = true
+	}
+}
+
+// Len returns the number of records in the tree.
+func (t *Tree[T]) Len() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.records)
+}
+
+// Record returns the record of the individual having the given ID, if known.
+func (t *Tree[T]) Record(id uint64) (Record, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	r, ok := t.records[id]
+	if !ok {
+		return Record{}, false
+	}
+	return r.clone(), true
+}
+
+// Ancestry returns the records of the individual having the given ID and of all
+// its known ancestors, sorted by ID.
+func (t *Tree[T]) Ancestry(id uint64) []Record {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var recs []Record
+	seen := map[uint64]bool{id: true}
+	queue := []uint64{id}
+	for len(queue) > 0 {
+		r, ok := t.records[queue[0]]
+		queue = queue[1:]
+		if !ok {
+			continue
+		}
+		recs = append(recs, r.clone())
+		for _, p := range r.Parents {
+			if !seen[p] {
+				seen[p] = true
+				queue = append(queue, p)
+			}
+		}
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].ID < recs[j].ID })
+	return recs
+}
+
+// Prune forgets the records of all individuals, except those of the
+// individuals having the given IDs and of their ancestors. It's typically
+// called with the IDs of the current population, in order to bound memory
+// usage.
+func (t *Tree[T]) Prune(keep ...uint64) {
+	kept := make(map[uint64]bool)
+	for _, id := range keep {
+		for _, r := range t.Ancestry(id) {
+			kept[r.ID] = true
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id := range t.records {
+		if !kept[id] {
+			delete(t.records, id)
+		}
+	}
+}
+
+// WriteDOT writes the ancestry tree of the individual having the given ID to w,
+// as a Graphviz DOT directed graph. Edges go from parents to children and are
+// labelled with the operators that produced the children.
+func (t *Tree[T]) WriteDOT(w io.Writer, id uint64) error {
+	var sb strings.Builder
+	sb.WriteString("digraph lineage {\n")
+	sb.WriteString("\tnode [shape=box];\n")
+	recs := t.Ancestry(id)
+	for _, r := range recs {
+		label := fmt.Sprintf("#%d\\ngeneration %d", r.ID, r.Generation)
+		if r.Evaluated {
+			label += fmt.Sprintf("\\nfitness %g", r.Fitness)
+		}
+		fmt.Fprintf(&sb, "\tn%d [label=\"%s\"];\n", r.ID, label)
+	}
+	for _, r := range recs {
+		ops := strings.ReplaceAll(strings.Join(r.Operators, ", "), `"`, `\"`)
+		for _, p := range r.Parents {
+			fmt.Fprintf(&sb, "\tn%d -> n%d [label=\"%s\"];\n", p, r.ID, ops)
+		}
+	}
+	sb.WriteString("}\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// WriteJSON writes the ancestry tree of the individual having the given ID to
+// w, as a JSON array of records, sorted by ID.
+func (t *Tree[T]) WriteJSON(w io.Writer, id uint64) error {
+	recs := t.Ancestry(id)
+	if recs == nil {
+		recs = []Record{}
+	}
+	return json.NewEncoder(w).Encode(recs)
+}
+
+func (r *Record) clone() Record {
+	c := *r
+	c.Parents = append([]uint64(nil), r.Parents...)
+	c.Operators = append([]string(nil), r.Operators...)
+	return c
+}
+
+func contains[E comparable](s []E, v E) bool {
+	for i := range s {
+		if s[i] == v {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique[E comparable](s []E, vals ...E) []E {
+	for _, v := range vals {
+		if !contains(s, v) {
+			s = append(s, v)
+		}
+	}
+	return s
+}
@@ -0,0 +1,221 @@
+package lineage
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/condition"
+	"github.com/arl/evolve/engine"
+	"github.com/arl/evolve/generator"
+	"github.com/arl/evolve/operator"
+	"github.com/arl/evolve/operator/mutation"
+	"github.com/arl/evolve/operator/xover"
+	"github.com/arl/evolve/selection"
+)
+
+// sumMater produces the sum and the difference of its parents.
+type sumMater struct{}
+
+func (sumMater) Mate(p1, p2 int, nxpts int, rng *rand.Rand) (int, int) {
+	return p1 + p2, p1 - p2
+}
+
+// incMutater increments even numbers and leaves odd numbers untouched.
+type incMutater struct{}
+
+func (incMutater) Mutate(v int, rng *rand.Rand) int {
+	if v%2 == 0 {
+		return v + 1
+	}
+	return v
+}
+
+func TestTreeRecords(t *testing.T) {
+	tree := NewTree[int]()
+	fac := &Factory[int]{Factory: evolve.FactoryFunc[int](func(*rand.Rand) int { return 2 }), Tree: tree}
+	eval := &Evaluator[int]{
+		Evaluator: evolve.EvaluatorFunc(true, func(v int, _ []int) float64 { return float64(v) }),
+		Tree:      tree,
+	}
+	mater := &Mater[int]{Mater: sumMater{}, Tree: tree, Name: "sum"}
+	mutater := &Mutater[int]{Mutater: incMutater{}, Tree: tree, Equal: func(a, b int) bool { return a == b }}
+
+	p1, p2 := fac.New(nil), fac.New(nil)
+	pop := []*Individual[int]{p1, p2}
+	eval.Fitness(p1, pop)
+	eval.Fitness(p2, pop)
+
+	tree.OnGenerationStart(1)
+	off1, off2 := mater.Mate(p1, p2, 1, nil)
+	mut1 := mutater.Mutate(off1, nil) // 4 is mutated into 5
+	mut2 := mutater.Mutate(off2, nil) // 0 is mutated into 1
+	if same := mutater.Mutate(mut1, nil); same != mut1 {
+		t.Errorf("unmutated individual should be returned as is")
+	}
+	eval.Fitness(mut1, []*Individual[int]{mut1, mut2})
+	eval.Fitness(mut2, []*Individual[int]{mut1, mut2})
+
+	r, ok := tree.Record(mut1.ID)
+	if !ok {
+		t.Fatalf("mutant not recorded")
+	}
+	if len(r.Parents) != 2 || r.Parents[0] != p1.ID || r.Parents[1] != p2.ID {
+		t.Errorf("parents = %v, want [%d %d]", r.Parents, p1.ID, p2.ID)
+	}
+	if strings.Join(r.Operators, ",") != "sum,lineage.incMutater" {
+		t.Errorf("operators = %v, want [sum lineage.incMutater]", r.Operators)
+	}
+	if r.Generation != 1 || !r.Evaluated || r.Fitness != 5 {
+		t.Errorf("got record %+v, want generation 1 and fitness 5", r)
+	}
+
+	// Intermediate offspring are forgotten at the next generation.
+	if tree.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", tree.Len())
+	}
+	tree.OnGenerationStart(2)
+	if _, ok := tree.Record(off1.ID); ok {
+		t.Errorf("intermediate offspring should have been forgotten")
+	}
+	if tree.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", tree.Len())
+	}
+
+	anc := tree.Ancestry(mut1.ID)
+	if len(anc) != 3 || anc[0].ID != p1.ID || anc[1].ID != p2.ID || anc[2].ID != mut1.ID {
+		t.Errorf("Ancestry = %+v, want records of p1, p2 and mut1", anc)
+	}
+
+	tree.Prune(mut2.ID)
+	if tree.Len() != 3 {
+		t.Errorf("after Prune, Len() = %d, want 3", tree.Len())
+	}
+	if _, ok := tree.Record(mut1.ID); ok {
+		t.Errorf("mut1 should have been pruned")
+	}
+}
+
+func TestEvaluatorReusedPopulation(t *testing.T) {
+	tree := NewTree[int]()
+	fac := &Factory[int]{Factory: evolve.FactoryFunc[int](func(*rand.Rand) int { return 2 }), Tree: tree}
+	// Evaluates the sum of the population genomes.
+	eval := &Evaluator[int]{
+		Evaluator: evolve.EvaluatorFunc(true, func(_ int, pop []int) float64 {
+			var sum int
+			for _, v := range pop {
+				sum += v
+			}
+			return float64(sum)
+		}),
+		Tree: tree,
+	}
+
+	pop := []*Individual[int]{fac.New(nil), fac.New(nil)}
+	if got := eval.Fitness(pop[0], pop); got != 4 {
+		t.Fatalf("Fitness = %v, want 4", got)
+	}
+
+	// The next generation reuses the population memory.
+	pop[0] = tree.add(10, "", pop[0])
+	if got := eval.Fitness(pop[0], pop); got != 12 {
+		t.Errorf("Fitness = %v, want 12, genomes of the previous population have been used", got)
+	}
+}
+
+func TestTreeExport(t *testing.T) {
+	tree := NewTree[int]()
+	p := tree.add(1, "")
+	tree.setFitness(p.ID, 1)
+	c := tree.add(2, `op"1`, p)
+	tree.setFitness(c.ID, 2)
+
+	var dot bytes.Buffer
+	if err := tree.WriteDOT(&dot, c.ID); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph lineage {
+	node [shape=box];
+	n1 [label="#1\ngeneration 0\nfitness 1"];
+	n2 [label="#2\ngeneration 0\nfitness 2"];
+	n1 -> n2 [label="op\"1"];
+}
+`
+	if dot.String() != want {
+		t.Errorf("WriteDOT got:\n%s\nwant:\n%s", dot.String(), want)
+	}
+
+	var buf bytes.Buffer
+	if err := tree.WriteJSON(&buf, c.ID); err != nil {
+		t.Fatal(err)
+	}
+	var recs []Record
+	if err := json.Unmarshal(buf.Bytes(), &recs); err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 || recs[1].Parents[0] != p.ID || recs[1].Operators[0] != `op"1` {
+		t.Errorf("WriteJSON got %s", buf.String())
+	}
+}
+
+func TestTreeEngine(t *testing.T) {
+	tree := NewTree[int]()
+	eval := &Evaluator[int]{
+		Evaluator: evolve.EvaluatorFunc(true, func(v int, _ []int) float64 {
+			if v < 0 {
+				return 0
+			}
+			return float64(v)
+		}),
+		Tree: tree,
+	}
+
+	xo := xover.New[*Individual[int]](&Mater[int]{Mater: sumMater{}, Tree: tree, Name: "sum"})
+	xo.Points = generator.Const(1)
+	xo.Probability = generator.Const(0.5)
+
+	eng := engine.Engine[*Individual[int]]{
+		Factory: &Factory[int]{
+			Factory: evolve.FactoryFunc[int](func(rng *rand.Rand) int { return rng.Intn(10) }),
+			Tree:    tree,
+		},
+		Evaluator: eval,
+		Epocher: &engine.Generational[*Individual[int]]{
+			Operator: operator.Pipeline[*Individual[int]]{
+				xo,
+				mutation.New[*Individual[int]](&Mutater[int]{Mutater: incMutater{}, Tree: tree, Name: "inc"}),
+			},
+			Evaluator: eval,
+			Selection: &selection.Tournament[*Individual[int]]{Probability: generator.Const(0.9)},
+			Elites:    1,
+		},
+		EndConditions: []evolve.Condition[*Individual[int]]{condition.GenerationCount[*Individual[int]](5)},
+		Observers:     []engine.Observer[*Individual[int]]{tree},
+		Seed:          1,
+	}
+
+	pop, _, err := eng.Evolve(20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	best := pop.Candidates[0]
+	anc := tree.Ancestry(best.ID)
+	if len(anc) == 0 || anc[len(anc)-1].ID != best.ID {
+		t.Fatalf("best individual ancestry not found: %+v", anc)
+	}
+	for _, r := range anc {
+		if !r.Evaluated {
+			t.Errorf("ancestor %d hasn't been evaluated", r.ID)
+		}
+		if len(r.Parents) == 0 && r.Generation != 0 {
+			t.Errorf("ancestor %d without parents born in generation %d", r.ID, r.Generation)
+		}
+	}
+	if anc[0].Generation != 0 {
+		t.Errorf("oldest ancestor born in generation %d, want 0", anc[0].Generation)
+	}
+}
@@ -0,0 +1,133 @@
+package lineage
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/operator/mutation"
+	"github.com/arl/evolve/operator/xover"
+)
+
+// Factory adapts a factory of T into a factory of individuals, recording
+// their birth in Tree.
+type Factory[T any] struct {
+	Factory evolve.Factory[T]
+	Tree    *Tree[T]
+}
+
+// New returns a new random individual.
+func (f *Factory[T]) New(rng *rand.Rand) *Individual[T] {
+	return f.Tree.add(f.Factory.New(rng), "")
+}
+
+// Evaluator adapts an evaluator of T into an evaluator of individuals,
+// recording their fitness in Tree.
+type Evaluator[T any] struct {
+	Evaluator evolve.Evaluator[T]
+	Tree      *Tree[T]
+
+	mu      sync.Mutex
+	pop     []*Individual[T] // population whose genomes are cached
+	genomes []T
+}
+
+// Fitness returns the fitness of the individual genome.
+func (e *Evaluator[T]) Fitness(ind *Individual[T], pop []*Individual[T]) float64 {
+	fitness := e.Evaluator.Fitness(ind.Genome, e.popGenomes(pop))
+	e.Tree.setFitness(ind.ID, fitness)
+	return fitness
+}
+
+// IsNatural returns the naturalness of the adapted evaluator.
+func (e *Evaluator[T]) IsNatural() bool { return e.Evaluator.IsNatural() }
+
+// popGenomes returns the genomes of the individuals of pop. Since all the
+// individuals of a population are evaluated in turn, the genomes of the last
+// population are cached.
+func (e *Evaluator[T]) popGenomes(pop []*Individual[T]) []T {
+	if len(pop) == 0 {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.cached(pop) {
+		e.pop = append(e.pop[:0], pop...)
+		e.genomes = make([]T, len(pop))
+		for i, ind := range pop {
+			e.genomes[i] = ind.Genome
+		}
+	}
+	return e.genomes
+}
+
+// cached reports whether the genomes of pop are cached. Individuals are
+// compared, rather than the population memory, which may be reused from a
+// generation to the next. e.mu must be held.
+func (e *Evaluator[T]) cached(pop []*Individual[T]) bool {
+	if len(e.pop) != len(pop) {
+		return false
+	}
+	for i := range pop {
+		if e.pop[i] != pop[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Mater adapts a crossover Mater of T into a Mater of individuals, recording
+// the birth of the offspring in Tree. It's meant to be used by
+// xover.Crossover.
+type Mater[T any] struct {
+	Mater xover.Mater[T]
+	Tree  *Tree[T]
+
+	// Name is the operator name recorded for the offspring. If empty, it
+	// defaults to the type name of the adapted Mater.
+	Name string
+}
+
+// Mate performs crossover on the genomes of a pair of parents to generate a
+// pair of offspring.
+func (m *Mater[T]) Mate(parent1, parent2 *Individual[T], nxpts int, rng *rand.Rand) (*Individual[T], *Individual[T]) {
+	off1, off2 := m.Mater.Mate(parent1.Genome, parent2.Genome, nxpts, rng)
+	name := m.Name
+	if name == "" {
+		name = fmt.Sprintf("%T", m.Mater)
+	}
+	return m.Tree.add(off1, name, parent1, parent2), m.Tree.add(off2, name, parent1, parent2)
+}
+
+// Mutater adapts a Mutater of T into a Mutater of individuals, recording the
+// birth of the mutants in Tree. It's meant to be used by mutation.Mutation.
+type Mutater[T any] struct {
+	Mutater mutation.Mutater[T]
+	Tree    *Tree[T]
+
+	// Name is the operator name recorded for the mutants. If empty, it
+	// defaults to the type name of the adapted Mutater.
+	Name string
+
+	// Equal, if set, reports whether 2 genomes are equal. It's used to detect
+	// that no mutation has been performed, in which case the original
+	// individual is returned. If nil, all mutants are considered new
+	// individuals.
+	Equal func(a, b T) bool
+}
+
+// Mutate performs mutation on the genome of an individual.
+func (m *Mutater[T]) Mutate(ind *Individual[T], rng *rand.Rand) *Individual[T] {
+	mutant := m.Mutater.Mutate(ind.Genome, rng)
+	if m.Equal != nil && m.Equal(mutant, ind.Genome) {
+		return ind
+	}
+	name := m.Name
+	if name == "" {
+		name = fmt.Sprintf("%T", m.Mutater)
+	}
+	return m.Tree.add(mutant, name, ind)
+}
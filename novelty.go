@@ -0,0 +1,172 @@
+package evolve
+
+import (
+	"sort"
+	"sync"
+)
+
+// Novelty is a fitness evaluator implementing novelty search. It rewards
+// candidates exhibiting a behavior that differs from what has been seen so far,
+// rather than candidates getting closer to an objective. This helps with
+// deceptive problems, in which following the objective leads to local optima.
+//
+// The novelty score of a candidate is the average distance between its behavior
+// and the behaviors of its K nearest neighbours, among the current population
+// and an archive of past behaviors. Candidates whose novelty score exceeds
+// Threshold see their behavior added to the archive, once the whole generation
+// has been evaluated. Novelty must thus be registered as an observer of the
+// evolution engine, so as to know when a generation is over.
+//
+// If Wrapped is not nil, novelty is blended with the fitness computed by the
+// wrapped evaluator, using Weight. A Weight of 0 gives pure novelty search,
+// while a Weight of 1 gives pure objective search. Since novelty scores are
+// natural, so are blended scores: when the wrapped evaluator isn't natural, its
+// fitness f is converted into 1/(1+f) before blending.
+//
+// Novelty is safe for concurrent use.
+type Novelty[T, B any] struct {
+	// Behavior computes the behavior of a candidate.
+	Behavior func(T) B
+
+	// Distance returns the distance between 2 behaviors.
+	Distance func(a, b B) float64
+
+	// K is the number of nearest neighbours considered to compute the novelty
+	// score of a candidate.
+	K int
+
+	// Threshold is the novelty score above which the behavior of a candidate
+	// is added to the archive.
+	Threshold float64
+
+	// Wrapped is an optional evaluator, whose fitness is blended with novelty.
+	Wrapped Evaluator[T]
+
+	// Weight is the weight given to the fitness of the wrapped evaluator, in
+	// [0, 1].
+	Weight float64
+
+	mu        sync.Mutex
+	archive   []B
+	pending   []novelBehavior[B] // behaviors to archive at the end of the generation
+	pop       *T                 // first element of the population whose behaviors are cached
+	behaviors []B
+}
+
+// A novelBehavior is a behavior waiting to be archived.
+type novelBehavior[B any] struct {
+	behavior B
+	novelty  float64
+}
+
+// Fitness returns the novelty score of cand, possibly blended with the fitness
+// computed by the wrapped evaluator.
+func (n *Novelty[T, B]) Fitness(cand T, pop []T) float64 {
+	behavior := n.Behavior(cand)
+	popb := n.popBehaviors(pop)
+
+	n.mu.Lock()
+	dists := make([]float64, 0, len(popb)+len(n.archive))
+	for _, b := range popb {
+		dists = append(dists, n.Distance(behavior, b))
+	}
+	sort.Float64s(dists)
+	if len(dists) > 0 {
+		// cand is part of the population, ignore the distance to itself.
+		dists = dists[1:]
+	}
+	for _, b := range n.archive {
+		dists = append(dists, n.Distance(behavior, b))
+	}
+	sort.Float64s(dists)
+
+	k := n.K
+	if k > len(dists) {
+		k = len(dists)
+	}
+	var novelty float64
+	for _, d := range dists[:k] {
+		novelty += d
+	}
+	if k > 0 {
+		novelty /= float64(k)
+	}
+
+	if novelty > n.Threshold {
+		n.pending = append(n.pending, novelBehavior[B]{behavior, novelty})
+	}
+	n.mu.Unlock()
+
+	if n.Wrapped == nil {
+		return novelty
+	}
+	fitness := n.Wrapped.Fitness(cand, pop)
+	if !n.Wrapped.IsNatural() {
+		fitness = 1 / (1 + fitness)
+	}
+	return (1-n.Weight)*novelty + n.Weight*fitness
+}
+
+// IsNatural always returns true, the more novel a candidate, the higher its
+// score.
+func (n *Novelty[T, B]) IsNatural() bool { return true }
+
+// Observe adds to the archive the behaviors of the candidates of the last
+// generation whose novelty score exceeded Threshold, the most novel first.
+// Behaviors already archived aren't added again.
+func (n *Novelty[T, B]) Observe(*PopulationStats[T]) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	// Candidates are evaluated in any order, sort them so that the archive
+	// doesn't depend on it.
+	sort.SliceStable(n.pending, func(i, j int) bool {
+		return n.pending[i].novelty > n.pending[j].novelty
+	})
+	for _, p := range n.pending {
+		if !n.archived(p.behavior) {
+			n.archive = append(n.archive, p.behavior)
+		}
+	}
+	n.pending = n.pending[:0]
+
+	// The next generation is a new population, even if it reuses the memory
+	// of the previous one.
+	n.pop, n.behaviors = nil, nil
+}
+
+// archived reports whether b is already in the archive. n.mu must be held.
+func (n *Novelty[T, B]) archived(b B) bool {
+	for _, a := range n.archive {
+		if n.Distance(a, b) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Archive returns a copy of the archive of behaviors.
+func (n *Novelty[T, B]) Archive() []B {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]B(nil), n.archive...)
+}
+
+// popBehaviors returns the behaviors of all the candidates of pop. Behaviors
+// are computed once per population and per generation.
+func (n *Novelty[T, B]) popBehaviors(pop []T) []B {
+	if len(pop) == 0 {
+		return nil
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.pop != &pop[0] || len(n.behaviors) != len(pop) {
+		n.behaviors = make([]B, len(pop))
+		for i := range pop {
+			n.behaviors[i] = n.Behavior(pop[i])
+		}
+		n.pop = &pop[0]
+	}
+	return n.behaviors
+}
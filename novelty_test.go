@@ -0,0 +1,113 @@
+package evolve
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func newIntNovelty(k int, threshold float64) *Novelty[int, float64] {
+	return &Novelty[int, float64]{
+		Behavior:  func(i int) float64 { return float64(i) },
+		Distance:  func(a, b float64) float64 { return math.Abs(a - b) },
+		K:         k,
+		Threshold: threshold,
+	}
+}
+
+func TestNoveltyScore(t *testing.T) {
+	pop := []int{0, 1, 2, 10}
+	eval := newIntNovelty(2, math.MaxFloat64)
+
+	tests := []struct {
+		cand int
+		want float64
+	}{
+		{cand: 0, want: 1.5},  // neighbours: 1, 2
+		{cand: 1, want: 1},    // neighbours: 0, 2
+		{cand: 10, want: 8.5}, // neighbours: 2, 1
+	}
+	for _, tt := range tests {
+		if got := eval.Fitness(tt.cand, pop); got != tt.want {
+			t.Errorf("Fitness(%d) = %v, want %v", tt.cand, got, tt.want)
+		}
+	}
+	if !eval.IsNatural() {
+		t.Errorf("novelty should be natural")
+	}
+}
+
+func TestNoveltyArchive(t *testing.T) {
+	eval := newIntNovelty(1, 5)
+
+	// 10 is far from its nearest neighbour, it gets archived.
+	pop := []int{0, 1, 10}
+	eval.Fitness(0, pop)
+	eval.Fitness(1, pop)
+	eval.Fitness(10, pop)
+	if got := eval.Archive(); len(got) != 0 {
+		t.Fatalf("archive = %v, want it empty until the end of the generation", got)
+	}
+	eval.Observe(&PopulationStats[int]{})
+	if got := eval.Archive(); len(got) != 1 || got[0] != 10 {
+		t.Fatalf("archive = %v, want [10]", got)
+	}
+
+	// In another population, a candidate identical to an archived behavior
+	// isn't novel anymore.
+	pop = []int{10, 20}
+	if got := eval.Fitness(10, pop); got != 0 {
+		t.Errorf("Fitness(10) = %v, want 0", got)
+	}
+}
+
+func TestNoveltyBlend(t *testing.T) {
+	pop := []int{0, 4}
+	eval := newIntNovelty(1, math.MaxFloat64)
+	eval.Wrapped = EvaluatorFunc(true, func(i int, _ []int) float64 { return float64(i) })
+	eval.Weight = 0.25
+
+	// novelty=4, fitness=4
+	if got := eval.Fitness(4, pop); got != 4 {
+		t.Errorf("Fitness(4) = %v, want 4", got)
+	}
+	// novelty=4, fitness=0
+	if got := eval.Fitness(0, pop); got != 3 {
+		t.Errorf("Fitness(0) = %v, want 3", got)
+	}
+
+	// Non-natural wrapped fitness f is blended as 1/(1+f).
+	eval.Wrapped = EvaluatorFunc(false, func(i int, _ []int) float64 { return float64(i) })
+	if got := eval.Fitness(0, pop); got != 0.75*4+0.25 {
+		t.Errorf("Fitness(0) = %v, want %v", got, 0.75*4+0.25)
+	}
+}
+
+func TestNoveltyGenerations(t *testing.T) {
+	eval := newIntNovelty(1, 5)
+
+	// The same population memory is reused across generations.
+	pop := []int{0, 10, 30}
+	for _, c := range pop {
+		eval.Fitness(c, pop)
+	}
+	// An elite candidate is evaluated again in the same generation.
+	eval.Fitness(30, pop)
+	eval.Observe(&PopulationStats[int]{})
+	if got := eval.Archive(); fmt.Sprint(got) != "[30 0 10]" {
+		t.Fatalf("archive = %v, want [30 0 10]", got)
+	}
+
+	copy(pop, []int{100, 101, 30})
+	if got := eval.Fitness(100, pop); got != 1 {
+		t.Errorf("Fitness(100) = %v, want 1, behaviors of the previous generation have been used", got)
+	}
+	eval.Fitness(101, pop)
+	eval.Fitness(30, pop)
+	eval.Observe(&PopulationStats[int]{})
+
+	// Only novel behaviors are archived.
+	if got := eval.Archive(); fmt.Sprint(got) != "[30 0 10]" {
+		t.Errorf("archive = %v, want [30 0 10]", got)
+	}
+}
@@ -41,3 +41,51 @@ type Operator[T any] interface {
 	// copied).
 	Apply([]T, *rand.Rand) []T
 }
+
+// A FeedbackOperator is an Operator that adapts itself according to the
+// fitness of the offspring it produces, such as an adaptive operator selection
+// scheme.
+//
+// Evolution engines supporting it, such as engine.Generational, call Feedback
+// once the offspring returned by the last call to Apply have been evaluated.
+type FeedbackOperator[T any] interface {
+	Operator[T]
+
+	// Feedback provides the fitness of the parents given to, and of the
+	// offspring returned by, the last call to Apply. fb and its slices are
+	// only valid for the duration of the call.
+	Feedback(fb *OperatorFeedback[T])
+}
+
+// OperatorFeedback describes the outcome of a call to Operator.Apply, once the
+// offspring have been evaluated.
+//
+// Offspring are compared to the parent they replace, that is the parent found
+// at the same index. The operators of this module respect that convention.
+type OperatorFeedback[T any] struct {
+	// Parents are the candidates given to Apply, and ParentFitness their
+	// fitness.
+	Parents       []T
+	ParentFitness []float64
+
+	// OffspringFitness holds the fitness of the offspring returned by Apply:
+	// OffspringFitness[i] is the fitness of the i-th offspring.
+	OffspringFitness []float64
+
+	// Natural indicates whether fitness scores are natural.
+	Natural bool
+}
+
+// Improvement returns the fitness improvement of the i-th offspring over its
+// parent, positive if the offspring is fitter, whatever the naturalness of the
+// fitness. It returns 0 if the offspring has no parent.
+func (fb *OperatorFeedback[T]) Improvement(i int) float64 {
+	if i >= len(fb.ParentFitness) || i >= len(fb.OffspringFitness) {
+		return 0
+	}
+	delta := fb.OffspringFitness[i] - fb.ParentFitness[i]
+	if !fb.Natural {
+		delta = -delta
+	}
+	return delta
+}
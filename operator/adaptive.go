@@ -0,0 +1,288 @@
+package operator
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/arl/evolve"
+)
+
+// A CreditStrategy is an adaptive operator selection scheme, defining how the
+// Adaptive operator picks an operator according to the credit it has been
+// assigned so far.
+type CreditStrategy int
+
+const (
+	// ProbabilityMatching picks operators with a probability proportional to
+	// their estimated quality, while guaranteeing each operator a minimum
+	// probability.
+	ProbabilityMatching CreditStrategy = iota
+
+	// AdaptivePursuit picks operators with probabilities that are pushed
+	// towards a maximum probability for the operator having the best estimated
+	// quality, and towards a minimum probability for the others.
+	AdaptivePursuit
+
+	// UCB picks the operator maximizing the UCB1 upper confidence bound of its
+	// reward, as in multi-armed bandits. Each group of candidates counts as a
+	// pull, so that operators compete within a generation.
+	UCB
+)
+
+// Adaptive is a compound operator holding a set of alternative operators. It
+// splits the selected candidates in groups, and applies a single operator to
+// each group, picked according to the credit previously assigned to each
+// operator.
+//
+// Operators are credited with the fitness improvement of their offspring over
+// the parent they replace. Since credit assignment requires the fitness of the
+// offspring, Adaptive implements evolve.FeedbackOperator, and must be used with
+// an engine providing that feedback, such as engine.Generational. Offspring
+// must keep the index of the candidate they replace, which is the case for the
+// operators of this module.
+type Adaptive[T any] struct {
+	// Operators are the alternative operators.
+	Operators []evolve.Operator[T]
+
+	// Strategy is the adaptive operator selection scheme.
+	Strategy CreditStrategy
+
+	// GroupSize is the number of candidates in each group to which a single
+	// operator is applied. If 0, it defaults to 2, so that crossover operators
+	// can be used.
+	GroupSize int
+
+	// MinProbability is the minimum probability for an operator to be picked,
+	// used by ProbabilityMatching and AdaptivePursuit. It must be lower than
+	// 1/len(Operators). If 0, it defaults to 1/(2*len(Operators)).
+	MinProbability float64
+
+	// LearningRate is the adaptation rate of the estimated operator qualities,
+	// in (0, 1], used by ProbabilityMatching and AdaptivePursuit. If 0, it
+	// defaults to 0.3.
+	LearningRate float64
+
+	// PursuitRate is the rate at which the probabilities are pushed towards
+	// their target, in (0, 1], used by AdaptivePursuit. If 0, it defaults to
+	// 0.8.
+	PursuitRate float64
+
+	// Exploration is the exploration coefficient of the UCB strategy. If 0,
+	// it defaults to √2.
+	Exploration float64
+
+	mu      sync.Mutex
+	init    bool
+	quality []float64
+	probs   []float64
+	counts  []int   // number of rewards credited to each operator (for UCB)
+	pulls   []int   // number of groups each operator has been applied to (for UCB)
+	total   int     // total number of pulls (for UCB)
+	maxr    float64 // largest offspring improvement seen so far
+	groups  []int   // group of each offspring of the last Apply
+	ops     []int   // operator applied to each group of the last Apply
+}
+
+func (a *Adaptive[T]) setup() {
+	if a.init {
+		return
+	}
+	n := len(a.Operators)
+	if a.GroupSize == 0 {
+		a.GroupSize = 2
+	}
+	if a.MinProbability == 0 {
+		a.MinProbability = 1 / (2 * float64(n))
+	}
+	if a.LearningRate == 0 {
+		a.LearningRate = 0.3
+	}
+	if a.PursuitRate == 0 {
+		a.PursuitRate = 0.8
+	}
+	if a.Exploration == 0 {
+		a.Exploration = math.Sqrt2
+	}
+	a.quality = make([]float64, n)
+	a.probs = make([]float64, n)
+	for i := range a.probs {
+		a.probs[i] = 1 / float64(n)
+	}
+	a.counts = make([]int, n)
+	a.pulls = make([]int, n)
+	a.init = true
+}
+
+// Apply splits sel into groups of GroupSize candidates, and applies to each
+// group an operator picked according to Strategy.
+func (a *Adaptive[T]) Apply(sel []T, rng *rand.Rand) []T {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.setup()
+
+	res := make([]T, 0, len(sel))
+	a.groups, a.ops = a.groups[:0], a.ops[:0]
+	for i := 0; i < len(sel); i += a.GroupSize {
+		end := i + a.GroupSize
+		if end > len(sel) {
+			end = len(sel)
+		}
+		op := a.pick(rng)
+		a.pulls[op]++
+		a.total++
+
+		off := a.Operators[op].Apply(sel[i:end], rng)
+		res = append(res, off...)
+		for range off {
+			a.groups = append(a.groups, len(a.ops))
+		}
+		a.ops = append(a.ops, op)
+	}
+	return res
+}
+
+// pick returns the index of the operator to apply to the next group.
+func (a *Adaptive[T]) pick(rng *rand.Rand) int {
+	if a.Strategy == UCB {
+		// Try each operator at least once, then pick the one with the highest
+		// upper confidence bound. Ties are broken at random.
+		var best []int
+		bestv := math.Inf(-1)
+		for i, n := range a.pulls {
+			v := math.Inf(1)
+			if n > 0 {
+				v = a.quality[i] + a.Exploration*math.Sqrt(math.Log(float64(a.total))/float64(n))
+			}
+			switch {
+			case v > bestv:
+				best, bestv = append(best[:0], i), v
+			case v == bestv:
+				best = append(best, i)
+			}
+		}
+		return best[rng.Intn(len(best))]
+	}
+
+	// Roulette wheel on operator probabilities.
+	r := rng.Float64()
+	for i, p := range a.probs {
+		if r < p {
+			return i
+		}
+		r -= p
+	}
+	return len(a.probs) - 1
+}
+
+// Feedback credits the operators applied during the last call to Apply with
+// the fitness improvement of their offspring over their parents, and updates
+// the operator probabilities.
+func (a *Adaptive[T]) Feedback(fb *evolve.OperatorFeedback[T]) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.setup()
+
+	// Compute the total improvement of the offspring of each group.
+	rewards := make([]float64, len(a.ops))
+	noff := make([]int, len(a.ops))
+	for i, g := range a.groups {
+		if i >= len(fb.OffspringFitness) || i >= len(fb.ParentFitness) {
+			break
+		}
+		imp := math.Max(0, fb.Improvement(i))
+		a.maxr = math.Max(a.maxr, imp)
+		rewards[g] += imp
+		noff[g]++
+	}
+
+	// Rewards are average improvements, normalized in [0, 1] by the largest
+	// improvement seen so far, so that the strategy parameters don't depend on
+	// the fitness scale.
+	norm := func(sum float64, n int) float64 {
+		if a.maxr == 0 {
+			return 0
+		}
+		return sum / float64(n) / a.maxr
+	}
+
+	if a.Strategy == UCB {
+		// Credit each pull with its reward, as a running average.
+		for g, op := range a.ops {
+			if noff[g] == 0 {
+				continue
+			}
+			a.counts[op]++
+			a.quality[op] += (norm(rewards[g], noff[g]) - a.quality[op]) / float64(a.counts[op])
+		}
+		return
+	}
+
+	// Credit each operator with the average reward of its offspring.
+	oprewards := make([]float64, len(a.Operators))
+	opnoff := make([]int, len(a.Operators))
+	for g, op := range a.ops {
+		oprewards[op] += rewards[g]
+		opnoff[op] += noff[g]
+	}
+	for i := range a.Operators {
+		if opnoff[i] == 0 {
+			continue
+		}
+		a.quality[i] += a.LearningRate * (norm(oprewards[i], opnoff[i]) - a.quality[i])
+	}
+	a.updateProbs()
+}
+
+// updateProbs updates operator probabilities from their estimated quality.
+func (a *Adaptive[T]) updateProbs() {
+	n := float64(len(a.Operators))
+	pmin := a.MinProbability
+	switch a.Strategy {
+	case ProbabilityMatching:
+		var sum float64
+		for _, q := range a.quality {
+			sum += q
+		}
+		for i, q := range a.quality {
+			if sum > 0 {
+				a.probs[i] = pmin + (1-n*pmin)*q/sum
+			} else {
+				a.probs[i] = 1 / n
+			}
+		}
+	case AdaptivePursuit:
+		pmax := 1 - (n-1)*pmin
+		best := 0
+		for i, q := range a.quality {
+			if q > a.quality[best] {
+				best = i
+			}
+		}
+		for i := range a.probs {
+			target := pmin
+			if i == best {
+				target = pmax
+			}
+			a.probs[i] += a.PursuitRate * (target - a.probs[i])
+		}
+	}
+}
+
+// Probabilities returns the current probability for each operator to be
+// picked. With the UCB strategy, operators are picked according to their upper
+// confidence bound and probabilities remain uniform.
+func (a *Adaptive[T]) Probabilities() []float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.setup()
+	return append([]float64(nil), a.probs...)
+}
+
+// Qualities returns the current estimated quality of each operator.
+func (a *Adaptive[T]) Qualities() []float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.setup()
+	return append([]float64(nil), a.quality...)
+}
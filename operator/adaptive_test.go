@@ -0,0 +1,138 @@
+package operator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+)
+
+func TestAdaptive(t *testing.T) {
+	strategies := map[CreditStrategy]string{
+		ProbabilityMatching: "probability matching",
+		AdaptivePursuit:     "adaptive pursuit",
+		UCB:                 "ucb",
+	}
+	for strategy, name := range strategies {
+		t.Run(name, func(t *testing.T) {
+			// With natural fitness, the first operator always improves
+			// candidates while the second always worsens them. Parents have
+			// different fitness values, so that the offspring of the second
+			// operator are often better than the population mean.
+			a := &Adaptive[int]{
+				Operators: []evolve.Operator[int]{adjustInt(5), adjustInt(-5)},
+				Strategy:  strategy,
+			}
+
+			rng := rand.New(rand.NewSource(99))
+			pop := &evolve.Population[int]{
+				Candidates: make([]int, 20),
+				Fitness:    make([]float64, 20),
+			}
+			for i := range pop.Candidates {
+				pop.Candidates[i] = 10 * i
+				pop.Fitness[i] = float64(10 * i)
+			}
+
+			var good int
+			for gen := 0; gen < 50; gen++ {
+				off := a.Apply(pop.Candidates, rng)
+				if len(off) != pop.Len() {
+					t.Fatalf("got %d offspring, want %d", len(off), pop.Len())
+				}
+				fitness := make([]float64, len(off))
+				good = 0
+				for i, c := range off {
+					fitness[i] = float64(c)
+					if c > pop.Candidates[i] {
+						good++
+					}
+				}
+				a.Feedback(&evolve.OperatorFeedback[int]{
+					Parents:          pop.Candidates,
+					ParentFitness:    pop.Fitness,
+					OffspringFitness: fitness,
+					Natural:          true,
+				})
+			}
+
+			q := a.Qualities()
+			if q[0] <= q[1] {
+				t.Errorf("qualities = %v, want the first operator to be better", q)
+			}
+			if good < 14 {
+				t.Errorf("got %d improved offspring in the last generation, want at least 14", good)
+			}
+			if strategy != UCB {
+				if p := a.Probabilities(); p[0] < 0.7 {
+					t.Errorf("probabilities = %v, want at least 0.7 for the first operator", p)
+				}
+			}
+		})
+	}
+}
+
+func TestAdaptiveUCB(t *testing.T) {
+	// Both operators improve candidates, the first one much more.
+	a := &Adaptive[int]{
+		Operators: []evolve.Operator[int]{adjustInt(10), adjustInt(1)},
+		Strategy:  UCB,
+	}
+
+	rng := rand.New(rand.NewSource(99))
+	sel := make([]int, 40)
+
+	// pulls returns the number of groups each operator has been applied to.
+	pulls := func(off []int) (n [2]int) {
+		for i := 0; i < len(off); i += a.GroupSize {
+			if off[i]-sel[i] == 10 {
+				n[0]++
+			} else {
+				n[1]++
+			}
+		}
+		return n
+	}
+
+	var last [2]int
+	for gen := 0; gen < 30; gen++ {
+		off := a.Apply(sel, rng)
+		last = pulls(off)
+		if gen == 0 && (last[0] == 0 || last[1] == 0) {
+			t.Errorf("both operators should be tried in the first generation, got %v pulls", last)
+		}
+		a.Feedback(feedback(sel, off))
+	}
+
+	if q := a.Qualities(); q[0] <= 2*q[1] {
+		t.Errorf("qualities = %v, want the first operator to be much better", q)
+	}
+	if last[0] < 3*last[1] {
+		t.Errorf("got %v pulls in the last generation, want the first operator to be favoured", last)
+	}
+}
+
+func TestPipelineFeedback(t *testing.T) {
+	// Both operators improve candidates.
+	a := &Adaptive[int]{Operators: []evolve.Operator[int]{adjustInt(1), adjustInt(2)}}
+	var op evolve.Operator[int] = Pipeline[int]{adjustInt(0), a}
+	fo, ok := op.(evolve.FeedbackOperator[int])
+	if !ok {
+		t.Fatal("Pipeline should implement FeedbackOperator")
+	}
+
+	rng := rand.New(rand.NewSource(99))
+	sel := []int{10, 10}
+	off := fo.Apply(sel, rng)
+	fo.Feedback(&evolve.OperatorFeedback[int]{
+		Parents:          sel,
+		ParentFitness:    []float64{10, 10},
+		OffspringFitness: []float64{float64(off[0]), float64(off[1])},
+		Natural:          true,
+	})
+
+	q := a.Qualities()
+	if q[0] == 0 && q[1] == 0 {
+		t.Errorf("feedback hasn't reached the adaptive operator, qualities = %v", q)
+	}
+}
@@ -0,0 +1,62 @@
+package operator
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/arl/evolve"
+)
+
+// A Choice is a compound evolutionary operator that applies one of several
+// operators, randomly chosen according to their weights, to the whole
+// selection.
+type Choice[T any] struct {
+	// Operators are the operators to choose from.
+	Operators []evolve.Operator[T]
+
+	// Weights are the relative weights of the operators. If nil, all
+	// operators have the same probability to be chosen. Otherwise, it must
+	// have the same length than Operators.
+	Weights []float64
+
+	mu     sync.Mutex
+	chosen int // index of the last chosen operator
+}
+
+// Apply applies one of the operators, chosen at random, to the selection.
+func (c *Choice[T]) Apply(sel []T, rng *rand.Rand) []T {
+	i := c.choose(rng)
+	c.mu.Lock()
+	c.chosen = i
+	c.mu.Unlock()
+	return c.Operators[i].Apply(sel, rng)
+}
+
+// choose returns the index of a randomly chosen operator.
+func (c *Choice[T]) choose(rng *rand.Rand) int {
+	if len(c.Weights) == 0 {
+		return rng.Intn(len(c.Operators))
+	}
+
+	var total float64
+	for _, w := range c.Weights {
+		total += w
+	}
+	r := rng.Float64() * total
+	for i, w := range c.Weights {
+		if r < w {
+			return i
+		}
+		r -= w
+	}
+	return len(c.Operators) - 1
+}
+
+// Feedback forwards the feedback to the operator chosen during the last call
+// to Apply, if it implements evolve.FeedbackOperator.
+func (c *Choice[T]) Feedback(fb *evolve.OperatorFeedback[T]) {
+	c.mu.Lock()
+	i := c.chosen
+	c.mu.Unlock()
+	forwardFeedback(c.Operators[i], fb)
+}
@@ -0,0 +1,64 @@
+package operator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+)
+
+func TestChoice(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	choice := &Choice[int]{
+		Operators: []evolve.Operator[int]{adjustInt(1), adjustInt(2), adjustInt(3)},
+		Weights:   []float64{1, 3, 0},
+	}
+
+	counts := make(map[int]int)
+	const iterations = 4000
+	for i := 0; i < iterations; i++ {
+		res := choice.Apply([]int{0, 0}, rng)
+		if res[0] != res[1] {
+			t.Fatalf("the same operator should be applied to the whole selection, got %v", res)
+		}
+		counts[res[0]]++
+	}
+
+	if counts[3] != 0 {
+		t.Errorf("operator with weight 0 applied %d times", counts[3])
+	}
+	if ratio := float64(counts[2]) / iterations; ratio < 0.7 || ratio > 0.8 {
+		t.Errorf("operator with weight 3/4 applied with a ratio of %v", ratio)
+	}
+
+	// Without weights, operators are equiprobable.
+	choice.Weights = nil
+	counts = make(map[int]int)
+	for i := 0; i < iterations; i++ {
+		counts[choice.Apply([]int{0}, rng)[0]]++
+	}
+	for v := 1; v <= 3; v++ {
+		if ratio := float64(counts[v]) / iterations; ratio < 0.3 || ratio > 0.37 {
+			t.Errorf("operator %d applied with a ratio of %v, want 1/3", v, ratio)
+		}
+	}
+}
+
+func TestChoiceFeedback(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	ops := []*feedbackInt{{adjustInt: 1}, {adjustInt: 2}}
+	choice := &Choice[int]{
+		Operators: []evolve.Operator[int]{ops[0], ops[1]},
+		Weights:   []float64{0, 1},
+	}
+
+	var _ evolve.FeedbackOperator[int] = choice
+	sel := []int{0, 0}
+	choice.Feedback(feedback(sel, choice.Apply(sel, rng)))
+	if ops[0].fb != nil {
+		t.Errorf("feedback forwarded to an operator that wasn't applied")
+	}
+	if ops[1].fb == nil || ops[1].fb.Improvement(0) != 2 {
+		t.Errorf("got feedback %+v, want an improvement of 2", ops[1].fb)
+	}
+}
@@ -0,0 +1,63 @@
+package operator
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/arl/evolve"
+)
+
+// A Conditional is an evolutionary operator that only applies an operator while
+// a predicate on the population statistics holds. Otherwise, the selection is
+// returned unchanged.
+//
+// Conditional must be registered as an observer of the evolution engine, so as
+// to know the statistics of the last generation.
+type Conditional[T any] struct {
+	// Operator is the operator applied while Predicate holds.
+	Operator evolve.Operator[T]
+
+	// Predicate reports whether Operator should be applied, given the
+	// statistics of the last observed generation. Before any generation has
+	// been observed, Predicate is given zero-valued statistics.
+	Predicate func(*evolve.PopulationStats[T]) bool
+
+	mu      sync.Mutex
+	stats   evolve.PopulationStats[T]
+	applied bool // whether Operator has been applied during the last Apply
+}
+
+// Observe records the population statistics, to be given to Predicate.
+func (c *Conditional[T]) Observe(stats *evolve.PopulationStats[T]) {
+	c.mu.Lock()
+	c.stats = *stats
+	c.mu.Unlock()
+}
+
+// Apply applies Operator to the selection if Predicate holds.
+func (c *Conditional[T]) Apply(sel []T, rng *rand.Rand) []T {
+	c.mu.Lock()
+	stats := c.stats
+	c.mu.Unlock()
+
+	apply := c.Predicate(&stats)
+	c.mu.Lock()
+	c.applied = apply
+	c.mu.Unlock()
+
+	if !apply {
+		return sel
+	}
+	return c.Operator.Apply(sel, rng)
+}
+
+// Feedback forwards the feedback to Operator, if it implements
+// evolve.FeedbackOperator and has been applied during the last call to Apply.
+func (c *Conditional[T]) Feedback(fb *evolve.OperatorFeedback[T]) {
+	c.mu.Lock()
+	applied := c.applied
+	c.mu.Unlock()
+	if applied {
+		forwardFeedback(c.Operator, fb)
+	}
+}
@@ -0,0 +1,57 @@
+package operator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+)
+
+func TestConditional(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	cond := &Conditional[int]{
+		Operator: adjustInt(1),
+		Predicate: func(stats *evolve.PopulationStats[int]) bool {
+			return stats.Generation < 3
+		},
+	}
+
+	// Before any generation has been observed, the predicate is given
+	// zero-valued statistics.
+	if res := cond.Apply([]int{0}, rng); res[0] != 1 {
+		t.Errorf("operator should be applied before the first generation")
+	}
+
+	for gen := 0; gen < 5; gen++ {
+		cond.Observe(&evolve.PopulationStats[int]{Generation: gen})
+		res := cond.Apply([]int{0}, rng)
+		if applied := res[0] == 1; applied != (gen < 3) {
+			t.Errorf("generation %d: applied = %t, want %t", gen, applied, gen < 3)
+		}
+	}
+}
+
+func TestConditionalFeedback(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	op := &feedbackInt{adjustInt: 1}
+	cond := &Conditional[int]{
+		Operator: op,
+		Predicate: func(stats *evolve.PopulationStats[int]) bool {
+			return stats.Generation < 1
+		},
+	}
+
+	var _ evolve.FeedbackOperator[int] = cond
+	sel := []int{0}
+	cond.Feedback(feedback(sel, cond.Apply(sel, rng)))
+	if op.fb == nil || op.fb.Improvement(0) != 1 {
+		t.Errorf("got feedback %+v, want an improvement of 1", op.fb)
+	}
+
+	op.fb = nil
+	cond.Observe(&evolve.PopulationStats[int]{Generation: 1})
+	cond.Feedback(feedback(sel, cond.Apply(sel, rng)))
+	if op.fb != nil {
+		t.Errorf("feedback forwarded to an operator that wasn't applied")
+	}
+}
@@ -0,0 +1,155 @@
+package operator
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+
+	"github.com/arl/evolve"
+)
+
+// Instrumented is a Pipeline that measures the contribution of each of its
+// stages: how many offspring each operator produced, how many of them are
+// fitter than the candidate they replace, and the average fitness improvement.
+//
+// An offspring is compared to the candidate it replaces, that is the candidate
+// found at the same index in the population given to the operator. The
+// operators of this module respect that convention.
+//
+// The fitness of the pipeline input and output is provided by the engine
+// through evolve.FeedbackOperator, so Instrumented must be used with an engine
+// providing that feedback, such as engine.Generational. Only the output of the
+// intermediate stages is evaluated by Instrumented, those evaluations are
+// reported by Evaluations.
+//
+// When Instrumented is the operator of an engine.Generational, the
+// contribution statistics are reported in the population statistics.
+type Instrumented[T any] struct {
+	// Pipeline holds the operators to apply in sequence.
+	Pipeline Pipeline[T]
+
+	// Evaluator evaluates the candidates produced by the intermediate stages.
+	Evaluator evolve.Evaluator[T]
+
+	// Names are the names of the pipeline stages. If a name is missing, it
+	// defaults to the type name of the operator.
+	Names []string
+
+	// Number of concurrent processes to use (defaults to the number of cores).
+	Concurrency int
+
+	mu    sync.Mutex
+	stats []evolve.OperatorStats
+	last  [][]float64 // fitness of the intermediate stages output
+	evals int
+}
+
+// Apply applies each operator in the pipeline in sequence to the selection.
+// The contribution of each operator is measured once the engine provides the
+// fitness of the final offspring with Feedback.
+func (p *Instrumented[T]) Apply(sel []T, rng *rand.Rand) []T {
+	if p.Concurrency == 0 {
+		p.Concurrency = runtime.NumCPU()
+	}
+
+	var (
+		last  [][]float64
+		evals int
+	)
+	for i, op := range p.Pipeline {
+		sel = op.Apply(sel, rng)
+		if i < len(p.Pipeline)-1 {
+			last = append(last, evolve.EvaluatePopulation(sel, p.Evaluator, p.Concurrency).Fitness)
+			evals += len(sel)
+		}
+	}
+
+	p.mu.Lock()
+	p.last, p.evals = last, evals
+	p.mu.Unlock()
+	return sel
+}
+
+// Evaluations returns the number of candidates evaluated during the last call
+// to Apply, that is the output of the intermediate stages.
+func (p *Instrumented[T]) Evaluations() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.evals
+}
+
+// Feedback measures the contribution of each stage during the last call to
+// Apply, then forwards the feedback to the pipeline operators implementing
+// evolve.FeedbackOperator.
+func (p *Instrumented[T]) Feedback(fb *evolve.OperatorFeedback[T]) {
+	p.mu.Lock()
+	last := p.last
+	p.last = nil
+	p.mu.Unlock()
+
+	if len(p.Pipeline) > 0 && len(last) == len(p.Pipeline)-1 {
+		fitness := fb.ParentFitness
+		for i, op := range p.Pipeline {
+			offfit := fb.OffspringFitness
+			if i < len(last) {
+				offfit = last[i]
+			}
+			stage := evolve.OperatorFeedback[T]{
+				ParentFitness:    fitness,
+				OffspringFitness: offfit,
+				Natural:          fb.Natural,
+			}
+
+			var st evolve.OperatorStats
+			for j := 0; j < len(offfit) && j < len(fitness); j++ {
+				delta := stage.Improvement(j)
+				st.Applied++
+				st.DeltaSum += delta
+				if delta > 0 {
+					st.Improved++
+				}
+			}
+			p.add(i, op, st)
+			fitness = offfit
+		}
+	}
+
+	p.Pipeline.Feedback(fb)
+}
+
+// add accumulates the statistics of the i-th stage.
+func (p *Instrumented[T]) add(i int, op evolve.Operator[T], st evolve.OperatorStats) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.stats) <= i {
+		p.stats = append(p.stats, evolve.OperatorStats{})
+	}
+	s := &p.stats[i]
+	if s.Name == "" {
+		if i < len(p.Names) && p.Names[i] != "" {
+			s.Name = p.Names[i]
+		} else {
+			s.Name = fmt.Sprintf("%T", op)
+		}
+	}
+	s.Applied += st.Applied
+	s.Improved += st.Improved
+	s.DeltaSum += st.DeltaSum
+}
+
+// OperatorStats returns the contribution statistics of each pipeline stage,
+// cumulated since the first call to Apply or the last call to Reset.
+func (p *Instrumented[T]) OperatorStats() []evolve.OperatorStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]evolve.OperatorStats(nil), p.stats...)
+}
+
+// Reset resets the contribution statistics.
+func (p *Instrumented[T]) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats = nil
+}
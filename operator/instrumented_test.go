@@ -0,0 +1,65 @@
+package operator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+)
+
+func TestInstrumented(t *testing.T) {
+	for _, natural := range []bool{true, false} {
+		pipe := &Instrumented[int]{
+			Pipeline:  Pipeline[int]{adjustInt(1), adjustInt(-3)},
+			Evaluator: evolve.EvaluatorFunc(natural, func(c int, _ []int) float64 { return float64(c) }),
+			Names:     []string{"plus1"},
+		}
+
+		rng := rand.New(rand.NewSource(99))
+		pop := []int{10, 20, 30, 40}
+		for gen := 0; gen < 2; gen++ {
+			off := pipe.Apply(pop, rng)
+			// Only the output of the first stage is evaluated.
+			if n := pipe.Evaluations(); n != 4 {
+				t.Errorf("natural=%t: Evaluations() = %d, want 4", natural, n)
+			}
+			fb := feedback(pop, off)
+			fb.Natural = natural
+			pipe.Feedback(fb)
+			pop = off
+		}
+		if pop[0] != 6 {
+			t.Errorf("natural=%t: pop[0] = %d, want 6", natural, pop[0])
+		}
+
+		stats := pipe.OperatorStats()
+		if len(stats) != 2 {
+			t.Fatalf("natural=%t: got %d operator stats, want 2", natural, len(stats))
+		}
+		// With non-natural fitness, lower is better.
+		want := []evolve.OperatorStats{
+			{Name: "plus1", Applied: 8, Improved: 8, DeltaSum: 8},
+			{Name: "operator.adjustInt", Applied: 8, Improved: 0, DeltaSum: -24},
+		}
+		if !natural {
+			want[0].Improved, want[0].DeltaSum = 0, -8
+			want[1].Improved, want[1].DeltaSum = 8, 24
+		}
+		for i := range want {
+			if stats[i] != want[i] {
+				t.Errorf("natural=%t: stats[%d] = %+v, want %+v", natural, i, stats[i], want[i])
+			}
+		}
+		if got := stats[0].MeanDelta(); natural && got != 1 {
+			t.Errorf("MeanDelta() = %v, want 1", got)
+		}
+		if got := stats[1].ImprovementRate(); !natural && got != 1 {
+			t.Errorf("ImprovementRate() = %v, want 1", got)
+		}
+
+		pipe.Reset()
+		if len(pipe.OperatorStats()) != 0 {
+			t.Errorf("natural=%t: stats should be empty after Reset", natural)
+		}
+	}
+}
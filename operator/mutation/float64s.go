@@ -0,0 +1,23 @@
+package mutation
+
+import "math/rand"
+
+// mutateGenes returns a copy of x in which each gene has been replaced, with
+// probability prob, by the value returned by f. x is returned if no gene has
+// been replaced, in which case mutated is false.
+func mutateGenes(x []float64, prob float64, rng *rand.Rand, f func(i int, v float64) float64) (mutant []float64, mutated bool) {
+	for i := range x {
+		if rng.Float64() >= prob {
+			continue
+		}
+		if mutant == nil {
+			mutant = make([]float64, len(x))
+			copy(mutant, x)
+		}
+		mutant[i] = f(i, mutant[i])
+	}
+	if mutant == nil {
+		return x, false
+	}
+	return mutant, true
+}
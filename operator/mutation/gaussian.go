@@ -0,0 +1,37 @@
+package mutation
+
+import (
+	"math/rand"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+)
+
+// Gaussian mutates the genes of real-valued vectors by adding them a normally
+// distributed perturbation, and repairs the genes that fall out of bounds.
+//
+// Probability governs the probability for each gene to be perturbed, and
+// StdDev the standard deviation of the perturbation.
+type Gaussian struct {
+	Probability generator.Float
+	StdDev      generator.Float
+
+	// Bounds, if set, holds the range of each gene. Out-of-range genes are
+	// repaired according to Repair.
+	Bounds *evolve.Bounds
+	Repair evolve.RepairStrategy
+}
+
+// Mutate returns a mutated copy of x. x is returned if no gene has been
+// perturbed.
+func (op *Gaussian) Mutate(x []float64, rng *rand.Rand) []float64 {
+	prob, stddev := op.Probability.Next(), op.StdDev.Next()
+
+	mutant, mutated := mutateGenes(x, prob, rng, func(_ int, v float64) float64 {
+		return v + rng.NormFloat64()*stddev
+	})
+	if mutated && op.Bounds != nil {
+		op.Bounds.Repair(mutant, op.Repair, rng)
+	}
+	return mutant
+}
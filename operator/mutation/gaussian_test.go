@@ -0,0 +1,48 @@
+package mutation
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGaussianMutation(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bounds := evolve.UniformBounds(5, -1, 1)
+
+	for _, repair := range []evolve.RepairStrategy{
+		evolve.RepairClip, evolve.RepairReflect, evolve.RepairWrap, evolve.RepairResample,
+	} {
+		mut := New[[]float64](&Gaussian{
+			Probability: generator.Const(0.5),
+			StdDev:      generator.Const(2.0),
+			Bounds:      &bounds,
+			Repair:      repair,
+		})
+
+		orig := []float64{0, 0.5, -0.5, 1, -1}
+		pop := [][]float64{orig}
+		for i := 0; i < 20; i++ {
+			pop = mut.Apply(pop, rng)
+			require.Len(t, pop[0], 5)
+			require.Truef(t, bounds.Contains(pop[0]), "repair %v: mutant out of bounds: %v", repair, pop[0])
+		}
+		require.Equal(t, []float64{0, 0.5, -0.5, 1, -1}, orig, "original individual has been modified")
+	}
+}
+
+func TestGaussianMutationNoop(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	op := &Gaussian{
+		Probability: generator.Const(0.0),
+		StdDev:      generator.Const(1.0),
+	}
+
+	x := []float64{1, 2, 3}
+	mutant := op.Mutate(x, rng)
+	require.Equal(t, &x[0], &mutant[0], "unmutated individual should be returned as is")
+}
@@ -0,0 +1,93 @@
+package mutation
+
+import (
+	"math/rand"
+
+	"github.com/arl/evolve/generator"
+)
+
+// Insertion mutates permutations by moving a random element to another random
+// position, shifting the elements in between.
+//
+// Probability governs the probability for each permutation to be mutated, and
+// Count the number of moves performed on a mutated permutation. If Count is
+// nil, a single element is moved.
+type Insertion[T any] struct {
+	Probability generator.Float
+	Count       generator.Generator[int]
+}
+
+// Mutate returns a mutated copy of s. s is returned if no mutation is
+// performed.
+func (op *Insertion[T]) Mutate(s []T, rng *rand.Rand) []T {
+	return mutatePermutation(s, op.Probability, op.Count, rng, func(s []T) {
+		displace(s, 1, false, rng)
+	})
+}
+
+// Displacement mutates permutations by moving a random segment to another
+// random position.
+//
+// Probability governs the probability for each permutation to be mutated, and
+// Count the number of moves performed on a mutated permutation. If Count is
+// nil, a single segment is moved.
+type Displacement[T any] struct {
+	Probability generator.Float
+	Count       generator.Generator[int]
+}
+
+// Mutate returns a mutated copy of s. s is returned if no mutation is
+// performed.
+func (op *Displacement[T]) Mutate(s []T, rng *rand.Rand) []T {
+	return mutatePermutation(s, op.Probability, op.Count, rng, func(s []T) {
+		// The segment can't be the whole permutation.
+		displace(s, 1+rng.Intn(len(s)-1), false, rng)
+	})
+}
+
+// OrOpt mutates permutations with or-opt moves: a short segment, of up to
+// MaxLength elements, is moved to another random position, possibly reversed.
+// Or-opt is a classic local search move for routing problems.
+//
+// Probability governs the probability for each permutation to be mutated, and
+// Count the number of moves performed on a mutated permutation. If Count is
+// nil, a single segment is moved.
+type OrOpt[T any] struct {
+	Probability generator.Float
+	Count       generator.Generator[int]
+
+	// MaxLength is the maximum length of the moved segments. If 0, it
+	// defaults to 3.
+	MaxLength int
+}
+
+// Mutate returns a mutated copy of s. s is returned if no mutation is
+// performed.
+func (op *OrOpt[T]) Mutate(s []T, rng *rand.Rand) []T {
+	maxlen := op.MaxLength
+	if maxlen == 0 {
+		maxlen = 3
+	}
+	return mutatePermutation(s, op.Probability, op.Count, rng, func(s []T) {
+		if maxlen > len(s)-1 {
+			maxlen = len(s) - 1
+		}
+		displace(s, 1+rng.Intn(maxlen), rng.Intn(2) == 0, rng)
+	})
+}
+
+// displace moves a random segment of s, of length l, to another random
+// position, reversing it if rev is true.
+func displace[T any](s []T, l int, rev bool, rng *rand.Rand) {
+	i := rng.Intn(len(s) - l + 1)
+
+	// Pick a different start position for the moved segment.
+	k := rng.Intn(len(s) - l)
+	if k >= i {
+		k++
+	}
+	if rev {
+		reverse(s[i : i+l])
+	}
+	moveSegment(s, i, l, k)
+}
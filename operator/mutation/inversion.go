@@ -0,0 +1,51 @@
+package mutation
+
+import (
+	"math/rand"
+
+	"github.com/arl/evolve/generator"
+)
+
+// Inversion mutates permutations by reversing the order of the elements of a
+// random segment. On TSP tours, it's equivalent to a 2-opt move: only the 2
+// edges at the ends of the segment are modified.
+//
+// Probability governs the probability for each permutation to be mutated, and
+// Count the number of inversions performed on a mutated permutation. If Count
+// is nil, a single inversion is performed.
+type Inversion[T any] struct {
+	Probability generator.Float
+	Count       generator.Generator[int]
+}
+
+// Mutate returns a mutated copy of s. s is returned if no mutation is
+// performed.
+func (op *Inversion[T]) Mutate(s []T, rng *rand.Rand) []T {
+	return mutatePermutation(s, op.Probability, op.Count, rng, func(s []T) {
+		i, j := randomSegment(len(s), rng)
+		reverse(s[i:j])
+	})
+}
+
+// Scramble mutates permutations by randomly shuffling the elements of a random
+// segment.
+//
+// Probability governs the probability for each permutation to be mutated, and
+// Count the number of segments shuffled on a mutated permutation. If Count is
+// nil, a single segment is shuffled.
+type Scramble[T any] struct {
+	Probability generator.Float
+	Count       generator.Generator[int]
+}
+
+// Mutate returns a mutated copy of s. s is returned if no mutation is
+// performed.
+func (op *Scramble[T]) Mutate(s []T, rng *rand.Rand) []T {
+	return mutatePermutation(s, op.Probability, op.Count, rng, func(s []T) {
+		i, j := randomSegment(len(s), rng)
+		seg := s[i:j]
+		rng.Shuffle(len(seg), func(i, j int) {
+			seg[i], seg[j] = seg[j], seg[i]
+		})
+	})
+}
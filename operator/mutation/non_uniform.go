@@ -0,0 +1,65 @@
+package mutation
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+)
+
+// NonUniform mutates the genes of real-valued vectors using Michalewicz's
+// non-uniform mutation, whose magnitude shrinks as the evolution progresses: at
+// first genes are perturbed over their whole range, then perturbations get
+// closer and closer to 0, which fine-tunes the solutions. Mutants are always
+// within bounds.
+//
+// Probability governs the probability for each gene to be perturbed.
+type NonUniform struct {
+	Probability generator.Float
+
+	// Bounds holds the range of each gene.
+	Bounds evolve.Bounds
+
+	// Clock measures the evolution progress, typically the clock of the
+	// engine, in generations. It's required.
+	Clock *generator.Clock
+
+	// Duration is the expected duration of the evolution, in Clock units.
+	// Perturbations are null once Duration is reached, or if Duration is not
+	// positive.
+	Duration int
+
+	// Shape controls how fast perturbations shrink, higher values shrink them
+	// faster. If 0, it defaults to 5.
+	Shape float64
+}
+
+// Mutate returns a mutated copy of x. x is returned if no gene has been
+// perturbed.
+func (op *NonUniform) Mutate(x []float64, rng *rand.Rand) []float64 {
+	b := op.Shape
+	if b == 0 {
+		b = 5
+	}
+	t := 1.0
+	if op.Duration > 0 {
+		t = math.Min(1, float64(op.Clock.Now())/float64(op.Duration))
+	}
+	exp := math.Pow(1-t, b)
+
+	mutant, _ := mutateGenes(x, op.Probability.Next(), rng, func(i int, v float64) float64 {
+		// Magnitude of the perturbation, shrinking with time, towards the
+		// upper or the lower bound.
+		delta := func(y float64) float64 {
+			return y * (1 - math.Pow(rng.Float64(), exp))
+		}
+		if rng.Float64() < 0.5 {
+			v += delta(op.Bounds.Max[i] - v)
+		} else {
+			v -= delta(v - op.Bounds.Min[i])
+		}
+		return math.Max(op.Bounds.Min[i], math.Min(op.Bounds.Max[i], v))
+	})
+	return mutant
+}
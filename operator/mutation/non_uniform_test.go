@@ -0,0 +1,58 @@
+package mutation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonUniformMutation(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bounds := evolve.UniformBounds(3, -10, 10)
+	clock := &generator.Clock{}
+
+	op := &NonUniform{
+		Probability: generator.Const(1.0),
+		Bounds:      bounds,
+		Clock:       clock,
+		Duration:    100,
+	}
+
+	// spread returns the mean perturbation magnitude at generation gen.
+	spread := func(gen int) float64 {
+		clock.Set(gen)
+		var sum float64
+		for i := 0; i < 1000; i++ {
+			x := op.Mutate([]float64{-10, 0, 9}, rng)
+			require.Truef(t, bounds.Contains(x), "mutant out of bounds: %v", x)
+			sum += math.Abs(x[1])
+		}
+		return sum / 1000
+	}
+
+	s0, s50, s90 := spread(0), spread(50), spread(90)
+	assert.Less(t, s50, s0)
+	assert.Less(t, s90, s50)
+	assert.Zero(t, spread(100), "perturbations should be null once duration is reached")
+}
+
+func TestNonUniformMutationZeroDuration(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	op := &NonUniform{
+		Probability: generator.Const(1.0),
+		Bounds:      evolve.UniformBounds(3, -10, 10),
+		Clock:       &generator.Clock{},
+	}
+
+	// With no duration, the evolution is considered complete from the start.
+	x := []float64{-10, 0, 9}
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, x, op.Mutate(x, rng))
+	}
+}
@@ -0,0 +1,67 @@
+package mutation
+
+import (
+	"math/rand"
+
+	"github.com/arl/evolve/generator"
+)
+
+// mutatePermutation returns a mutated copy of x, on which f has been applied
+// count times, with probability prob. x is returned if no mutation is
+// performed.
+func mutatePermutation[T any](x []T, prob generator.Float, count generator.Generator[int], rng *rand.Rand, f func([]T)) []T {
+	if len(x) < 2 || rng.Float64() >= prob.Next() {
+		return x
+	}
+	n := 1
+	if count != nil {
+		n = count.Next()
+	}
+	if n <= 0 {
+		return x
+	}
+
+	mutant := make([]T, len(x))
+	copy(mutant, x)
+	for i := 0; i < n; i++ {
+		f(mutant)
+	}
+	return mutant
+}
+
+// randomSegment returns the bounds of a random segment [i, j), of at least 2
+// elements, of a slice of n elements.
+func randomSegment(n int, rng *rand.Rand) (i, j int) {
+	i, j = rng.Intn(n-1), rng.Intn(n-1)
+	if i > j {
+		i, j = j, i
+	}
+	return i, j + 2
+}
+
+// moveSegment moves the segment of s starting at i and of length l, so that it
+// starts at k once moved. k must be in [0, len(s)-l].
+func moveSegment[T any](s []T, i, l, k int) {
+	switch {
+	case k < i:
+		// Rotate s[k:i+l] to the right by l.
+		rotate(s[k:i+l], l)
+	case k > i:
+		// Rotate s[i:k+l] to the left by l, that is to the right by k-i.
+		rotate(s[i:k+l], k-i)
+	}
+}
+
+// rotate rotates s to the right by r, that is the last r elements move to the
+// front.
+func rotate[T any](s []T, r int) {
+	reverse(s)
+	reverse(s[:r])
+	reverse(s[r:])
+}
+
+func reverse[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
@@ -0,0 +1,159 @@
+package mutation
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/arl/evolve/factory"
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// permutationMutaters holds all the mutations operating on permutations.
+func permutationMutaters(prob float64, count generator.Generator[int]) map[string]Mutater[[]int] {
+	p := generator.Const(prob)
+	return map[string]Mutater[[]int]{
+		"inversion":    &Inversion[int]{Probability: p, Count: count},
+		"scramble":     &Scramble[int]{Probability: p, Count: count},
+		"insertion":    &Insertion[int]{Probability: p, Count: count},
+		"displacement": &Displacement[int]{Probability: p, Count: count},
+		"or-opt":       &OrOpt[int]{Probability: p, Count: count},
+	}
+}
+
+func isPermutation(s, want []int) bool {
+	if len(s) != len(want) {
+		return false
+	}
+	a, b := append([]int(nil), s...), append([]int(nil), want...)
+	sort.Ints(a)
+	sort.Ints(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPermutationMutationsValidity(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	counts := []generator.Generator[int]{nil, generator.Const(3), generator.Uniform(0, 5, rng)}
+
+	for _, count := range counts {
+		for name, m := range permutationMutaters(1, count) {
+			t.Run(name, func(t *testing.T) {
+				for _, n := range []int{0, 1, 2, 3, 4, 7, 20} {
+					items := make([]int, n)
+					for i := range items {
+						items[i] = i * 3
+					}
+					f := factory.Permutation[int](items)
+
+					for i := 0; i < 200; i++ {
+						p := f.New(rng)
+						orig := make([]int, len(p))
+						copy(orig, p)
+
+						mutant := m.Mutate(p, rng)
+						require.Truef(t, isPermutation(mutant, items), "n=%d: invalid mutant %v of %v", n, mutant, p)
+						require.Equal(t, orig, p, "original individual has been modified")
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPermutationMutationsProbability(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	p := []int{0, 1, 2, 3, 4, 5, 6, 7}
+
+	for name, m := range permutationMutaters(0, nil) {
+		mutant := m.Mutate(p, rng)
+		assert.Equalf(t, &p[0], &mutant[0], "%s: individual should be returned as is", name)
+	}
+	for name, m := range permutationMutaters(1, generator.Const(0)) {
+		mutant := m.Mutate(p, rng)
+		assert.Equalf(t, &p[0], &mutant[0], "%s: individual should be returned as is", name)
+	}
+	for name, m := range permutationMutaters(1, nil) {
+		if name == "scramble" {
+			// Scramble may shuffle a segment back to its original order.
+			continue
+		}
+		for i := 0; i < 100; i++ {
+			assert.NotEqualf(t, p, m.Mutate(p, rng), "%s: a mutation should modify the permutation", name)
+		}
+	}
+}
+
+func TestInversion(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	op := &Inversion[int]{Probability: generator.Const(1.0)}
+
+	// A single inversion modifies exactly 2 adjacencies (cyclically), or none
+	// if the whole permutation is reversed.
+	p := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	for i := 0; i < 100; i++ {
+		mutant := op.Mutate(p, rng)
+		broken := 0
+		for j := range mutant {
+			d := mutant[(j+1)%len(mutant)] - mutant[j]
+			if d != 1 && d != -1 && d != 9 && d != -9 {
+				broken++
+			}
+		}
+		assert.Containsf(t, []int{0, 2}, broken, "mutant: %v", mutant)
+	}
+}
+
+func TestMoveSegment(t *testing.T) {
+	tests := []struct {
+		i, l, k int
+		want    []int
+	}{
+		{i: 1, l: 2, k: 4, want: []int{0, 3, 4, 5, 1, 2}},
+		{i: 3, l: 3, k: 0, want: []int{3, 4, 5, 0, 1, 2}},
+		{i: 5, l: 1, k: 2, want: []int{0, 1, 5, 2, 3, 4}},
+		{i: 2, l: 1, k: 2, want: []int{0, 1, 2, 3, 4, 5}},
+	}
+	for _, tt := range tests {
+		s := []int{0, 1, 2, 3, 4, 5}
+		moveSegment(s, tt.i, tt.l, tt.k)
+		assert.Equalf(t, tt.want, s, "moveSegment(i=%d, l=%d, k=%d)", tt.i, tt.l, tt.k)
+	}
+}
+
+func TestOrOptSegmentLength(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	op := &OrOpt[int]{Probability: generator.Const(1.0), MaxLength: 1}
+
+	// With segments of length 1, or-opt moves a single element: removing it
+	// from both the original and the mutant gives the same sequence.
+	p := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	for i := 0; i < 100; i++ {
+		mutant := op.Mutate(p, rng)
+		var moved int
+		for j := range p {
+			a, b := without(p, p[j]), without(mutant, p[j])
+			if assert.ObjectsAreEqual(a, b) {
+				moved++
+			}
+		}
+		assert.GreaterOrEqualf(t, moved, 1, "mutant: %v", mutant)
+	}
+}
+
+func without(s []int, v int) []int {
+	var res []int
+	for _, x := range s {
+		if x != v {
+			res = append(res, x)
+		}
+	}
+	return res
+}
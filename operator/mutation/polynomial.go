@@ -0,0 +1,57 @@
+package mutation
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+)
+
+// Polynomial mutates the genes of real-valued vectors using polynomial
+// mutation, the mutation operator usually associated with simulated binary
+// crossover (xover.SBX). Perturbations follow a polynomial distribution scaled
+// to the range of each gene, so that mutants are always within bounds.
+//
+// Probability governs the probability for each gene to be perturbed, typically
+// 1/n for vectors of n genes.
+type Polynomial struct {
+	Probability generator.Float
+
+	// Eta is the distribution index. Large values produce mutants close to the
+	// original individual, while small values allow distant mutants. If 0, it
+	// defaults to 20.
+	Eta float64
+
+	// Bounds holds the range of each gene.
+	Bounds evolve.Bounds
+}
+
+// Mutate returns a mutated copy of x. x is returned if no gene has been
+// perturbed.
+func (op *Polynomial) Mutate(x []float64, rng *rand.Rand) []float64 {
+	eta := op.Eta
+	if eta == 0 {
+		eta = 20
+	}
+	pow := 1 / (eta + 1)
+
+	mutant, _ := mutateGenes(x, op.Probability.Next(), rng, func(i int, v float64) float64 {
+		lo, hi := op.Bounds.Min[i], op.Bounds.Max[i]
+		if hi == lo {
+			return lo
+		}
+		d1, d2 := (v-lo)/(hi-lo), (hi-v)/(hi-lo)
+
+		var deltaq float64
+		if u := rng.Float64(); u < 0.5 {
+			val := 2*u + (1-2*u)*math.Pow(1-d1, eta+1)
+			deltaq = math.Pow(val, pow) - 1
+		} else {
+			val := 2*(1-u) + 2*(u-0.5)*math.Pow(1-d2, eta+1)
+			deltaq = 1 - math.Pow(val, pow)
+		}
+		return math.Max(lo, math.Min(hi, v+deltaq*(hi-lo)))
+	})
+	return mutant
+}
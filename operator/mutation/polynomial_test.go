@@ -0,0 +1,51 @@
+package mutation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolynomialMutation(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bounds, err := evolve.NewBounds([]float64{-1, 0, 5}, []float64{1, 10, 5})
+	require.NoError(t, err)
+
+	mut := New[[]float64](&Polynomial{
+		Probability: generator.Const(1.0),
+		Bounds:      bounds,
+	})
+
+	pop := [][]float64{{-1, 10, 5}, {0, 5, 5}}
+	for i := 0; i < 100; i++ {
+		pop = mut.Apply(pop, rng)
+		for _, x := range pop {
+			require.Truef(t, bounds.Contains(x), "mutant out of bounds: %v", x)
+		}
+	}
+}
+
+func TestPolynomialDistributionIndex(t *testing.T) {
+	// Large distribution indices produce mutants closer to the original.
+	spread := func(eta float64) float64 {
+		rng := rand.New(rand.NewSource(99))
+		op := &Polynomial{
+			Probability: generator.Const(1.0),
+			Eta:         eta,
+			Bounds:      evolve.UniformBounds(1, 0, 1),
+		}
+		var sum float64
+		for i := 0; i < 1000; i++ {
+			sum += math.Abs(op.Mutate([]float64{0.5}, rng)[0] - 0.5)
+		}
+		return sum
+	}
+
+	assert.Less(t, spread(50), spread(5))
+}
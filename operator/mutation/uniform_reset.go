@@ -0,0 +1,29 @@
+package mutation
+
+import (
+	"math/rand"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+)
+
+// UniformReset mutates the genes of real-valued vectors by replacing them with
+// values uniformly distributed within bounds.
+//
+// Probability governs the probability for each gene to be replaced.
+type UniformReset struct {
+	Probability generator.Float
+
+	// Bounds holds the range of each gene.
+	Bounds evolve.Bounds
+}
+
+// Mutate returns a mutated copy of x. x is returned if no gene has been
+// replaced.
+func (op *UniformReset) Mutate(x []float64, rng *rand.Rand) []float64 {
+	mutant, _ := mutateGenes(x, op.Probability.Next(), rng, func(i int, _ float64) float64 {
+		lo, hi := op.Bounds.Min[i], op.Bounds.Max[i]
+		return lo + rng.Float64()*(hi-lo)
+	})
+	return mutant
+}
@@ -0,0 +1,32 @@
+package mutation
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUniformResetMutation(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bounds, err := evolve.NewBounds([]float64{-1, 100}, []float64{1, 200})
+	require.NoError(t, err)
+
+	op := &UniformReset{Probability: generator.Const(1.0), Bounds: bounds}
+
+	var sum float64
+	for i := 0; i < 1000; i++ {
+		x := op.Mutate([]float64{0, 150}, rng)
+		require.Truef(t, bounds.Contains(x), "mutant out of bounds: %v", x)
+		sum += x[1]
+	}
+	assert.InEpsilon(t, 150, sum/1000, 0.02)
+
+	op.Probability = generator.Const(0.0)
+	x := []float64{0, 150}
+	assert.Equal(t, &x[0], &op.Mutate(x, rng)[0])
+}
@@ -0,0 +1,128 @@
+package mutation
+
+import (
+	"math/rand"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+)
+
+// mutateLength returns a mutated copy of x, on which f has been applied count
+// times, with probability prob. f returns the modified slice, or false if the
+// modification isn't possible, in which case no more modifications are
+// attempted. x is returned if no mutation is performed.
+func mutateLength[T any](x []T, prob generator.Float, count generator.Generator[int], rng *rand.Rand, f func([]T) ([]T, bool)) []T {
+	if rng.Float64() >= prob.Next() {
+		return x
+	}
+	n := 1
+	if count != nil {
+		n = count.Next()
+	}
+
+	// f may modify its argument, always work on a copy of x.
+	mutant := make([]T, len(x), len(x)+1)
+	copy(mutant, x)
+	mutated := false
+	for i := 0; i < n; i++ {
+		next, ok := f(mutant)
+		if !ok {
+			break
+		}
+		mutant, mutated = next, true
+	}
+	if !mutated {
+		return x
+	}
+	return mutant
+}
+
+// Insert mutates slices by inserting new genes at random positions.
+//
+// Probability governs the probability for each slice to be mutated, and Count
+// the number of genes inserted in a mutated slice. If Count is nil, a single
+// gene is inserted.
+type Insert[T any] struct {
+	Probability generator.Float
+	Count       generator.Generator[int]
+
+	// Gene creates the inserted genes.
+	Gene evolve.Factory[T]
+
+	// MaxLength, if not 0, is the maximum length of the mutants. No genes are
+	// inserted in slices having that length.
+	MaxLength int
+}
+
+// Mutate returns a mutated copy of s. s is returned if no mutation is
+// performed.
+func (op *Insert[T]) Mutate(s []T, rng *rand.Rand) []T {
+	return mutateLength(s, op.Probability, op.Count, rng, func(s []T) ([]T, bool) {
+		if op.MaxLength != 0 && len(s) >= op.MaxLength {
+			return s, false
+		}
+		return insert(s, rng.Intn(len(s)+1), op.Gene.New(rng)), true
+	})
+}
+
+// Delete mutates slices by deleting genes at random positions.
+//
+// Probability governs the probability for each slice to be mutated, and Count
+// the number of genes deleted from a mutated slice. If Count is nil, a single
+// gene is deleted.
+type Delete[T any] struct {
+	Probability generator.Float
+	Count       generator.Generator[int]
+
+	// MinLength is the minimum length of the mutants. No genes are deleted
+	// from slices having that length.
+	MinLength int
+}
+
+// Mutate returns a mutated copy of s. s is returned if no mutation is
+// performed.
+func (op *Delete[T]) Mutate(s []T, rng *rand.Rand) []T {
+	return mutateLength(s, op.Probability, op.Count, rng, func(s []T) ([]T, bool) {
+		if len(s) == 0 || len(s) <= op.MinLength {
+			return s, false
+		}
+		i := rng.Intn(len(s))
+		return append(s[:i], s[i+1:]...), true
+	})
+}
+
+// Duplicate mutates slices by duplicating genes: a copy of a random gene is
+// inserted right after it.
+//
+// Probability governs the probability for each slice to be mutated, and Count
+// the number of genes duplicated in a mutated slice. If Count is nil, a single
+// gene is duplicated.
+type Duplicate[T any] struct {
+	Probability generator.Float
+	Count       generator.Generator[int]
+
+	// MaxLength, if not 0, is the maximum length of the mutants. No genes are
+	// duplicated in slices having that length.
+	MaxLength int
+}
+
+// Mutate returns a mutated copy of s. s is returned if no mutation is
+// performed.
+func (op *Duplicate[T]) Mutate(s []T, rng *rand.Rand) []T {
+	return mutateLength(s, op.Probability, op.Count, rng, func(s []T) ([]T, bool) {
+		if len(s) == 0 || (op.MaxLength != 0 && len(s) >= op.MaxLength) {
+			return s, false
+		}
+		i := rng.Intn(len(s))
+		return insert(s, i+1, s[i]), true
+	})
+}
+
+// insert inserts v in s at index i.
+func insert[T any](s []T, i int, v T) []T {
+	var zero T
+	s = append(s, zero)
+	copy(s[i+1:], s[i:])
+	s[i] = v
+	return s
+}
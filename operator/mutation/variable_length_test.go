@@ -0,0 +1,107 @@
+package mutation
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertMutation(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	op := &Insert[int]{
+		Probability: generator.Const(1.0),
+		Count:       generator.Const(2),
+		Gene:        evolve.FactoryFunc[int](func(*rand.Rand) int { return -1 }),
+		MaxLength:   6,
+	}
+
+	s := []int{1, 2, 3}
+	mutant := op.Mutate(s, rng)
+	require.Len(t, mutant, 5)
+	assert.Equal(t, []int{1, 2, 3}, s, "original individual has been modified")
+	assert.Equal(t, []int{1, 2, 3}, without(mutant, -1), "original genes should keep their order")
+
+	// Mutants never exceed the maximum length.
+	for i := 0; i < 10; i++ {
+		mutant = op.Mutate(mutant, rng)
+		require.LessOrEqual(t, len(mutant), 6)
+	}
+	assert.Len(t, mutant, 6)
+
+	mutant = op.Mutate(nil, rng)
+	assert.Equal(t, []int{-1, -1}, mutant)
+}
+
+func TestDeleteMutation(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	op := &Delete[int]{
+		Probability: generator.Const(1.0),
+		Count:       generator.Const(2),
+		MinLength:   2,
+	}
+
+	s := []int{1, 2, 3, 4, 5}
+	mutant := op.Mutate(s, rng)
+	require.Len(t, mutant, 3)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s, "original individual has been modified")
+	for i := 1; i < len(mutant); i++ {
+		assert.Less(t, mutant[i-1], mutant[i], "remaining genes should keep their order")
+	}
+
+	// Mutants never go below the minimum length, in which case the original
+	// individual is returned.
+	mutant = op.Mutate(mutant, rng)
+	require.Len(t, mutant, 2)
+	again := op.Mutate(mutant, rng)
+	assert.Equal(t, &mutant[0], &again[0])
+
+	op.MinLength = 0
+	assert.Empty(t, op.Mutate([]int{1}, rng))
+	assert.Empty(t, op.Mutate(nil, rng))
+}
+
+func TestDuplicateMutation(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	op := &Duplicate[string]{
+		Probability: generator.Const(1.0),
+		MaxLength:   4,
+	}
+
+	s := []string{"a", "b", "c"}
+	mutant := op.Mutate(s, rng)
+	require.Len(t, mutant, 4)
+	assert.Equal(t, []string{"a", "b", "c"}, s, "original individual has been modified")
+
+	// The duplicated gene is next to the original.
+	var dups int
+	for i := 1; i < len(mutant); i++ {
+		if mutant[i] == mutant[i-1] {
+			dups++
+		}
+	}
+	assert.Equal(t, 1, dups)
+
+	again := op.Mutate(mutant, rng)
+	assert.Equal(t, &mutant[0], &again[0], "individual at maximum length should be returned as is")
+	assert.Nil(t, op.Mutate(nil, rng))
+}
+
+func TestVariableLengthMutationsProbability(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	s := []int{1, 2, 3}
+	gene := evolve.FactoryFunc[int](func(*rand.Rand) int { return 0 })
+
+	for _, m := range []Mutater[[]int]{
+		&Insert[int]{Probability: generator.Const(0.0), Gene: gene},
+		&Delete[int]{Probability: generator.Const(0.0)},
+		&Duplicate[int]{Probability: generator.Const(0.0)},
+	} {
+		mutant := m.Mutate(s, rng)
+		assert.Equal(t, &s[0], &mutant[0])
+	}
+}
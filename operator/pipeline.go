@@ -17,3 +17,21 @@ func (ops Pipeline[T]) Apply(sel []T, rng *rand.Rand) []T {
 	}
 	return sel
 }
+
+// Feedback forwards the feedback to the operators of the pipeline implementing
+// evolve.FeedbackOperator. Since the offspring fitness is only known at the end
+// of the pipeline, each operator is credited with the improvement of the final
+// offspring over the pipeline input. Operators following a FeedbackOperator
+// must keep offspring at the index of the candidate they replace.
+func (ops Pipeline[T]) Feedback(fb *evolve.OperatorFeedback[T]) {
+	for _, op := range ops {
+		forwardFeedback(op, fb)
+	}
+}
+
+// forwardFeedback forwards fb to op, if it implements evolve.FeedbackOperator.
+func forwardFeedback[T any](op evolve.Operator[T], fb *evolve.OperatorFeedback[T]) {
+	if fo, ok := op.(evolve.FeedbackOperator[T]); ok {
+		fo.Feedback(fb)
+	}
+}
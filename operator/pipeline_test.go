@@ -3,6 +3,8 @@ package operator
 import (
 	"math/rand"
 	"testing"
+
+	"github.com/arl/evolve"
 )
 
 // adjustInt mutates integers candidates by adding a fixed offset.
@@ -16,6 +18,30 @@ func (op adjustInt) Apply(cand []int, rng *rand.Rand) []int {
 	return result
 }
 
+// feedbackInt is an adjustInt recording the feedback it receives.
+type feedbackInt struct {
+	adjustInt
+	fb *evolve.OperatorFeedback[int]
+}
+
+func (op *feedbackInt) Feedback(fb *evolve.OperatorFeedback[int]) {
+	cpy := *fb
+	op.fb = &cpy
+}
+
+// feedback returns a natural fitness feedback where candidates are their own
+// fitness.
+func feedback(parents, offspring []int) *evolve.OperatorFeedback[int] {
+	fb := &evolve.OperatorFeedback[int]{Parents: parents, Natural: true}
+	for _, c := range parents {
+		fb.ParentFitness = append(fb.ParentFitness, float64(c))
+	}
+	for _, c := range offspring {
+		fb.OffspringFitness = append(fb.OffspringFitness, float64(c))
+	}
+	return fb
+}
+
 func TestEvolutionPipeline(t *testing.T) {
 	// Make sure that multiple operators in a pipeline are applied correctly
 	// to the population and validate the cumulative effects.
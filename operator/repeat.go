@@ -0,0 +1,51 @@
+package operator
+
+import (
+	"math/rand"
+	"sync"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+)
+
+// A Repeat is a compound evolutionary operator that applies an operator
+// multiple times in sequence to the selection, each application operating on
+// the result of the previous one.
+type Repeat[T any] struct {
+	// Operator is the repeated operator.
+	Operator evolve.Operator[T]
+
+	// Times generates, for each call to Apply, the number of times Operator
+	// is applied. If it generates 0 or a negative number, the selection is
+	// returned unchanged.
+	Times generator.Generator[int]
+
+	mu      sync.Mutex
+	applied bool // whether Operator has been applied during the last Apply
+}
+
+// Apply applies the operator repeatedly to the selection.
+func (r *Repeat[T]) Apply(sel []T, rng *rand.Rand) []T {
+	n := r.Times.Next()
+	for i := 0; i < n; i++ {
+		sel = r.Operator.Apply(sel, rng)
+	}
+
+	r.mu.Lock()
+	r.applied = n > 0
+	r.mu.Unlock()
+	return sel
+}
+
+// Feedback forwards the feedback to Operator, if it implements
+// evolve.FeedbackOperator and has been applied during the last call to Apply.
+// Offspring are then compared to the candidates given to the first
+// application.
+func (r *Repeat[T]) Feedback(fb *evolve.OperatorFeedback[T]) {
+	r.mu.Lock()
+	applied := r.applied
+	r.mu.Unlock()
+	if applied {
+		forwardFeedback(r.Operator, fb)
+	}
+}
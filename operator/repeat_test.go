@@ -0,0 +1,45 @@
+package operator
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+)
+
+func TestRepeat(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	for _, times := range []int{-1, 0, 1, 5} {
+		rep := &Repeat[int]{Operator: adjustInt(2), Times: generator.Const(times)}
+		res := rep.Apply([]int{10, 20}, rng)
+
+		n := times
+		if n < 0 {
+			n = 0
+		}
+		if res[0] != 10+2*n || res[1] != 20+2*n {
+			t.Errorf("times=%d: got %v, want operator applied %d times", times, res, n)
+		}
+	}
+}
+
+func TestRepeatFeedback(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	op := &feedbackInt{adjustInt: 2}
+	rep := &Repeat[int]{Operator: op, Times: generator.Const(3)}
+
+	var _ evolve.FeedbackOperator[int] = rep
+	sel := []int{10}
+	rep.Feedback(feedback(sel, rep.Apply(sel, rng)))
+	if op.fb == nil || op.fb.Improvement(0) != 6 {
+		t.Errorf("got feedback %+v, want an improvement of 6", op.fb)
+	}
+
+	op.fb = nil
+	rep.Times = generator.Const(0)
+	rep.Feedback(feedback(sel, rep.Apply(sel, rng)))
+	if op.fb != nil {
+		t.Errorf("feedback forwarded to an operator that wasn't applied")
+	}
+}
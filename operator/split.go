@@ -0,0 +1,81 @@
+package operator
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+)
+
+// A Split is a compound evolutionary operator that routes a fraction of the
+// selection through an operator, and the rest through another.
+//
+// The first part of the selection goes through First, while the remaining
+// candidates go through Second. Results are concatenated in the same order, so
+// that offspring keep the index of the candidate they replace.
+type Split[T any] struct {
+	// First is the operator applied to the first part of the selection.
+	First evolve.Operator[T]
+
+	// Second is the operator applied to the rest of the selection.
+	Second evolve.Operator[T]
+
+	// Fraction generates, for each call to Apply, the fraction of the
+	// selection, in [0, 1], that goes through First.
+	Fraction generator.Float
+
+	mu     sync.Mutex
+	nsel   int // number of candidates given to First during the last Apply
+	nfirst int // number of offspring returned by First during the last Apply
+}
+
+// Apply applies First to a fraction of the selection, and Second to the rest.
+func (s *Split[T]) Apply(sel []T, rng *rand.Rand) []T {
+	frac := math.Max(0, math.Min(1, s.Fraction.Next()))
+	n := int(math.Round(frac * float64(len(sel))))
+
+	var res []T
+	if n > 0 {
+		res = append(res, s.First.Apply(sel[:n], rng)...)
+	}
+	nfirst := len(res)
+	if n < len(sel) {
+		res = append(res, s.Second.Apply(sel[n:], rng)...)
+	}
+
+	s.mu.Lock()
+	s.nsel, s.nfirst = n, nfirst
+	s.mu.Unlock()
+	return res
+}
+
+// Feedback forwards to First and Second, if they implement
+// evolve.FeedbackOperator, the part of the feedback concerning the candidates
+// they have been given during the last call to Apply.
+func (s *Split[T]) Feedback(fb *evolve.OperatorFeedback[T]) {
+	s.mu.Lock()
+	nsel, nfirst := s.nsel, s.nfirst
+	s.mu.Unlock()
+
+	first, second := *fb, *fb
+	first.Parents, second.Parents = splitAt(fb.Parents, nsel)
+	first.ParentFitness, second.ParentFitness = splitAt(fb.ParentFitness, nsel)
+	first.OffspringFitness, second.OffspringFitness = splitAt(fb.OffspringFitness, nfirst)
+
+	if nsel > 0 {
+		forwardFeedback(s.First, &first)
+	}
+	if nsel < len(fb.Parents) {
+		forwardFeedback(s.Second, &second)
+	}
+}
+
+// splitAt splits s at index i, or at its end if i is out of range.
+func splitAt[E any](s []E, i int) ([]E, []E) {
+	if i > len(s) {
+		i = len(s)
+	}
+	return s[:i:i], s[i:]
+}
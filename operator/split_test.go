@@ -0,0 +1,75 @@
+package operator
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+)
+
+func TestSplit(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	sel := []int{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	tests := []struct {
+		frac      float64
+		wantFirst int
+	}{
+		{0.3, 3},
+		{0, 0},
+		{1, 10},
+		{1.5, 10},
+	}
+	for _, tt := range tests {
+		split := &Split[int]{
+			First:    adjustInt(1),
+			Second:   adjustInt(2),
+			Fraction: generator.Const(tt.frac),
+		}
+		res := split.Apply(sel, rng)
+		if len(res) != len(sel) {
+			t.Fatalf("fraction %v: got %d candidates, want %d", tt.frac, len(res), len(sel))
+		}
+		for i, c := range res {
+			want := 2
+			if i < tt.wantFirst {
+				want = 1
+			}
+			if c != want {
+				t.Errorf("fraction %v: got %v, want %d candidates through First", tt.frac, res, tt.wantFirst)
+				break
+			}
+		}
+	}
+}
+
+func TestSplitFeedback(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	first, second := &feedbackInt{adjustInt: 1}, &feedbackInt{adjustInt: 2}
+	split := &Split[int]{
+		First:    first,
+		Second:   second,
+		Fraction: generator.Const(0.4),
+	}
+
+	var _ evolve.FeedbackOperator[int] = split
+	sel := []int{0, 10, 20, 30, 40}
+	split.Feedback(feedback(sel, split.Apply(sel, rng)))
+
+	if fmt.Sprint(first.fb.Parents, first.fb.ParentFitness, first.fb.OffspringFitness) != "[0 10] [0 10] [1 11]" {
+		t.Errorf("got first feedback %+v, want the 2 first candidates", first.fb)
+	}
+	if fmt.Sprint(second.fb.Parents, second.fb.ParentFitness, second.fb.OffspringFitness) != "[20 30 40] [20 30 40] [22 32 42]" {
+		t.Errorf("got second feedback %+v, want the 3 last candidates", second.fb)
+	}
+
+	// Operators that haven't been given any candidate get no feedback.
+	first.fb, second.fb = nil, nil
+	split.Fraction = generator.Const(1.0)
+	split.Feedback(feedback(sel, split.Apply(sel, rng)))
+	if first.fb == nil || second.fb != nil {
+		t.Errorf("got feedbacks %+v and %+v, want only the first", first.fb, second.fb)
+	}
+}
@@ -0,0 +1,44 @@
+package xover
+
+import (
+	"math/rand"
+
+	"github.com/arl/evolve/generator"
+)
+
+// Arithmetic mates pairs of real-valued vectors using whole arithmetic
+// crossover: offspring are weighted averages of their parents, that is
+// w*p1+(1-w)*p2 and (1-w)*p1+w*p2.
+//
+// Since offspring genes are always between their parents genes, offspring of
+// parents within bounds are within bounds too.
+//
+// Arithmetic ignores the number of crossover points.
+type Arithmetic struct {
+	// Weight generates the weight w, in [0, 1], of each crossover. If nil, w
+	// is uniformly distributed.
+	Weight generator.Float
+}
+
+// Mate performs arithmetic crossover on a pair of parents to generate a pair
+// of offspring.
+func (m Arithmetic) Mate(p1, p2 []float64, _ int, rng *rand.Rand) (off1, off2 []float64) {
+	if len(p1) != len(p2) {
+		panic("Arithmetic only mates slices of the same length")
+	}
+
+	var w float64
+	if m.Weight == nil {
+		w = rng.Float64()
+	} else {
+		w = m.Weight.Next()
+	}
+
+	off1 = make([]float64, len(p1))
+	off2 = make([]float64, len(p2))
+	for i := range p1 {
+		off1[i] = w*p1[i] + (1-w)*p2[i]
+		off2[i] = (1-w)*p1[i] + w*p2[i]
+	}
+	return off1, off2
+}
@@ -0,0 +1,34 @@
+package xover
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArithmetic(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	m := Arithmetic{Weight: generator.Const(0.25)}
+
+	off1, off2 := m.Mate([]float64{0, 4}, []float64{8, 0}, 1, rng)
+	assert.Equal(t, []float64{6, 1}, off1)
+	assert.Equal(t, []float64{2, 3}, off2)
+}
+
+func TestArithmeticRandomWeight(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	m := Arithmetic{}
+
+	p1, p2 := []float64{-1, 2}, []float64{3, 2}
+	for i := 0; i < 100; i++ {
+		off1, off2 := m.Mate(p1, p2, 1, rng)
+		for _, x := range [][]float64{off1, off2} {
+			assert.True(t, x[0] >= -1 && x[0] <= 3, "gene out of parents interval: %v", x[0])
+			assert.Equal(t, 2.0, x[1])
+		}
+		assert.InDelta(t, 2, off1[0]+off2[0], 1e-9)
+	}
+}
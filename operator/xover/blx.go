@@ -0,0 +1,47 @@
+package xover
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/arl/evolve"
+)
+
+// BLX mates pairs of real-valued vectors using BLX-α blend crossover: each
+// offspring gene is uniformly distributed in the interval defined by the
+// parents genes, extended on both sides by Alpha times its length.
+//
+// BLX ignores the number of crossover points.
+type BLX struct {
+	// Alpha is the extension factor of the parents interval, typically 0.5.
+	// With 0, offspring genes are always between their parents genes.
+	Alpha float64
+
+	// Bounds, if set, holds the range of each gene. Out-of-range offspring
+	// genes are repaired according to Repair.
+	Bounds *evolve.Bounds
+	Repair evolve.RepairStrategy
+}
+
+// Mate performs blend crossover on a pair of parents to generate a pair of
+// offspring.
+func (m BLX) Mate(p1, p2 []float64, _ int, rng *rand.Rand) (off1, off2 []float64) {
+	if len(p1) != len(p2) {
+		panic("BLX only mates slices of the same length")
+	}
+
+	off1 = make([]float64, len(p1))
+	off2 = make([]float64, len(p2))
+	for i := range p1 {
+		lo, hi := math.Min(p1[i], p2[i]), math.Max(p1[i], p2[i])
+		ext := m.Alpha * (hi - lo)
+		lo, hi = lo-ext, hi+ext
+		off1[i] = lo + rng.Float64()*(hi-lo)
+		off2[i] = lo + rng.Float64()*(hi-lo)
+	}
+	if m.Bounds != nil {
+		m.Bounds.Repair(off1, m.Repair, rng)
+		m.Bounds.Repair(off2, m.Repair, rng)
+	}
+	return off1, off2
+}
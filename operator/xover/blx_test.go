@@ -0,0 +1,46 @@
+package xover
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLX(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	m := BLX{Alpha: 0.5}
+
+	p1, p2 := []float64{0, 10}, []float64{4, 10}
+	var outside bool
+	for i := 0; i < 1000; i++ {
+		off1, off2 := m.Mate(p1, p2, 1, rng)
+		for _, x := range [][]float64{off1, off2} {
+			require.True(t, x[0] >= -2 && x[0] <= 6, "gene out of extended interval: %v", x[0])
+			require.Equal(t, 10.0, x[1])
+			outside = outside || x[0] < 0 || x[0] > 4
+		}
+	}
+	assert.True(t, outside, "BLX-0.5 should produce genes outside of the parents interval")
+}
+
+func TestBLXBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bounds := evolve.UniformBounds(2, 0, 1)
+
+	for _, repair := range []evolve.RepairStrategy{
+		evolve.RepairClip, evolve.RepairReflect, evolve.RepairWrap, evolve.RepairResample,
+	} {
+		m := BLX{Alpha: 1, Bounds: &bounds, Repair: repair}
+		for i := 0; i < 100; i++ {
+			off1, off2 := m.Mate([]float64{0, 0.9}, []float64{0.2, 1}, 1, rng)
+			require.Truef(t, bounds.Contains(off1), "repair %v: offspring out of bounds: %v", repair, off1)
+			require.Truef(t, bounds.Contains(off2), "repair %v: offspring out of bounds: %v", repair, off2)
+		}
+	}
+
+	assert.Panics(t, func() { BLX{}.Mate([]float64{0}, []float64{0, 1}, 1, rng) })
+}
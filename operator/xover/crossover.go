@@ -48,7 +48,10 @@ func New[T any](mater Mater[T]) *Crossover[T] {
 // be eligible to reproduce.
 //
 // Returns the combined set of evolved offsprings generated by applying
-// crossover to the selected candidates.
+// crossover to the selected candidates. Each offspring takes the place of one
+// of its parents, that is the first offspring of a pair is at the same index
+// than the first parent in sel, and the second offspring at the same index
+// than the second parent.
 func (op *Crossover[T]) Apply(sel []T, rng *rand.Rand) []T {
 	// Generate a slice of 0..n indices (n=len(sel) and pair candidates using
 	// shuffled indices so that the evolution is not influenced by any ordering
@@ -58,12 +61,12 @@ func (op *Crossover[T]) Apply(sel []T, rng *rand.Rand) []T {
 		idx[i], idx[j] = idx[j], idx[i]
 	})
 
-	res := make([]T, 0, len(sel))
+	res := make([]T, len(sel))
 	for i := 0; i < len(sel); {
-		p1 := sel[idx[i]]
+		i1 := idx[i]
 		i++
 		if i < len(sel) {
-			p2 := sel[idx[i]]
+			i2 := idx[i]
 			i++
 
 			// Probability for this pair to be mated
@@ -76,17 +79,16 @@ func (op *Crossover[T]) Apply(sel []T, rng *rand.Rand) []T {
 				npts = int(op.Points.Next())
 			}
 			if npts > 0 {
-				off1, off2 := op.Mate(p1, p2, npts, rng)
-				res = append(res, off1, off2)
+				res[i1], res[i2] = op.Mate(sel[i1], sel[i2], npts, rng)
 			} else {
 				// If there is no crossover to perform, just add the parents to the
 				// results unaltered.
-				res = append(res, p1, p2)
+				res[i1], res[i2] = sel[i1], sel[i2]
 			}
 		} else {
 			// If we have an odd number of selected candidates, we can't pair up
 			// the last one so just leave it unmodified.
-			res = append(res, p1)
+			res[i1] = sel[i1]
 		}
 	}
 	return res
@@ -0,0 +1,44 @@
+package xover
+
+import "math/rand"
+
+// maxLengthAttempts is the number of times variable-length crossovers try to
+// pick cut points producing offspring of valid lengths, before giving up.
+const maxLengthAttempts = 10
+
+// CutSplice mates pairs of slices of possibly different lengths using
+// cut-and-splice crossover: a cut point is chosen independently in each parent,
+// and the offspring are made of the head of a parent followed by the tail of
+// the other. Offspring lengths thus differ from their parents lengths.
+//
+// CutSplice ignores the number of crossover points.
+type CutSplice[T any] struct {
+	// MinLength and MaxLength, if not 0, are the bounds of the offspring
+	// lengths. If no valid cut points are found, offspring are copies of their
+	// parents.
+	MinLength, MaxLength int
+}
+
+// Mate performs cut-and-splice crossover on a pair of parents to generate a
+// pair of offspring.
+func (m CutSplice[T]) Mate(p1, p2 []T, _ int, rng *rand.Rand) (off1, off2 []T) {
+	for i := 0; i < maxLengthAttempts; i++ {
+		c1, c2 := rng.Intn(len(p1)+1), rng.Intn(len(p2)+1)
+		if !m.valid(c1+len(p2)-c2) || !m.valid(c2+len(p1)-c1) {
+			continue
+		}
+		return splice(p1[:c1], p2[c2:]), splice(p2[:c2], p1[c1:])
+	}
+	return clone(p1), clone(p2)
+}
+
+func (m CutSplice[T]) valid(n int) bool {
+	return n >= m.MinLength && (m.MaxLength == 0 || n <= m.MaxLength)
+}
+
+// splice returns a new slice made of head followed by tail.
+func splice[T any](head, tail []T) []T {
+	s := make([]T, 0, len(head)+len(tail))
+	s = append(s, head...)
+	return append(s, tail...)
+}
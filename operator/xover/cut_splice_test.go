@@ -0,0 +1,58 @@
+package xover
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCutSplice(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	xover := New[[]int](CutSplice[int]{})
+	xover.Probability = generator.Const(1.0)
+	xover.Points = generator.Const(1)
+
+	// Parents hold increasing values, which makes the splice point visible.
+	p1 := []int{1, 2, 3, 4, 5}
+	p2 := []int{11, 12, 13}
+
+	lengths := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		off := xover.Apply([][]int{p1, p2}, rng)
+		require.Len(t, off, 2)
+
+		// Offspring keep all the genes of their parents.
+		require.Equal(t, len(p1)+len(p2), len(off[0])+len(off[1]))
+		for _, o := range off {
+			lengths[len(o)] = true
+		}
+	}
+	assert.Greater(t, len(lengths), 2, "offspring lengths should vary")
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, p1, "parent has been modified")
+}
+
+func TestCutSpliceLengthBounds(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	m := CutSplice[int]{MinLength: 3, MaxLength: 5}
+
+	p1 := []int{1, 2, 3, 4}
+	p2 := []int{5, 6, 7, 8}
+	for i := 0; i < 100; i++ {
+		off1, off2 := m.Mate(p1, p2, 1, rng)
+		for _, o := range [][]int{off1, off2} {
+			require.GreaterOrEqual(t, len(o), 3)
+			require.LessOrEqual(t, len(o), 5)
+		}
+	}
+
+	// Impossible bounds give copies of the parents.
+	m = CutSplice[int]{MinLength: 10}
+	off1, off2 := m.Mate(p1, p2, 1, rng)
+	assert.Equal(t, p1, off1)
+	assert.Equal(t, p2, off2)
+}
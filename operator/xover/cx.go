@@ -0,0 +1,41 @@
+package xover
+
+import "math/rand"
+
+// CX implements the cycle crossover, on permutations.
+//
+// The positions of the parents are partitioned into cycles, so that the
+// elements found at the positions of a cycle are the same in both parents. The
+// offspring inherit alternatively the cycles of each parent. Each element
+// keeps the absolute position it has in one of the parents, which makes CX
+// well suited to assignment problems such as QAP.
+//
+// CX ignores the number of crossover points, and is deterministic.
+type CX[T comparable] struct{}
+
+// Mate mates 2 parents and generates a pair of offsprings with CX.
+func (CX[T]) Mate(p1, p2 []T, _ int, _ *rand.Rand) (off1, off2 []T) {
+	checkPermutations("CX", p1, p2)
+
+	idx1 := make(map[T]int, len(p1))
+	for i, v := range p1 {
+		idx1[v] = i
+	}
+
+	off1, off2 = clone(p1), clone(p2)
+	visited := make([]bool, len(p1))
+	swap := false
+	for start := range p1 {
+		if visited[start] {
+			continue
+		}
+		for i := start; !visited[i]; i = idx1[p2[i]] {
+			visited[i] = true
+			if swap {
+				off1[i], off2[i] = p2[i], p1[i]
+			}
+		}
+		swap = !swap
+	}
+	return off1, off2
+}
@@ -0,0 +1,239 @@
+package xover
+
+import (
+	"math"
+	"math/rand"
+)
+
+// EAX implements a lightweight variant of the edge assembly crossover, on
+// permutations considered as cycles, that is TSP tours.
+//
+// The edges of both parents form a graph in which alternating cycles, made of
+// edges taken alternatively from each parent, can be found. EAX picks one
+// random alternating cycle, and produces an offspring by replacing, in the
+// first parent, the edges of the cycle that belong to the first parent by those
+// belonging to the second parent. The result is a set of subtours, merged back
+// into a single tour by exchanging pairs of edges. The second offspring is
+// produced the same way, with the parents roles swapped.
+//
+// Contrary to the full EAX, only one alternating cycle is applied, and no local
+// search is performed.
+//
+// EAX ignores the number of crossover points.
+type EAX[T comparable] struct {
+	// Distance, if set, returns the distance between 2 elements. It's used
+	// to merge subtours with the shortest edges. If nil, subtours are merged
+	// randomly.
+	Distance func(a, b T) float64
+}
+
+// Mate mates 2 parents and generates a pair of offsprings with EAX.
+func (x EAX[T]) Mate(p1, p2 []T, _ int, rng *rand.Rand) (off1, off2 []T) {
+	checkPermutations("EAX", p1, p2)
+	if len(p1) < 4 {
+		return clone(p1), clone(p2)
+	}
+
+	return x.eax(p1, p2, rng), x.eax(p2, p1, rng)
+}
+
+// tourGraph is the adjacency list of a graph of degree 2, whose vertices are
+// the positions of the elements in the first parent.
+type tourGraph [][]int
+
+func (g tourGraph) link(a, b int) {
+	g[a] = append(g[a], b)
+	g[b] = append(g[b], a)
+}
+
+func (g tourGraph) unlink(a, b int) {
+	g[a] = removeOne(g[a], b)
+	g[b] = removeOne(g[b], a)
+}
+
+func (g tourGraph) has(a, b int) bool {
+	for _, v := range g[a] {
+		if v == b {
+			return true
+		}
+	}
+	return false
+}
+
+// eax returns an offspring of p1 and p2, made from the edges of p1 modified
+// with one alternating cycle.
+func (x EAX[T]) eax(p1, p2 []T, rng *rand.Rand) []T {
+	n := len(p1)
+	idx := make(map[T]int, n)
+	for i, v := range p1 {
+		idx[v] = i
+	}
+
+	ga, gb := make(tourGraph, n), make(tourGraph, n)
+	for i := 0; i < n; i++ {
+		ga.link(i, (i+1)%n)
+		gb.link(idx[p2[i]], idx[p2[(i+1)%n]])
+	}
+
+	// Only keep, in both graphs, the edges that are not shared by the parents.
+	ra, rb := make(tourGraph, n), make(tourGraph, n)
+	var starts []int
+	for a := 0; a < n; a++ {
+		for _, b := range ga[a] {
+			if a < b && !gb.has(a, b) {
+				ra.link(a, b)
+			}
+		}
+		for _, b := range gb[a] {
+			if a < b && !ga.has(a, b) {
+				rb.link(a, b)
+			}
+		}
+		if len(ra[a]) > 0 {
+			starts = append(starts, a)
+		}
+	}
+	if len(starts) == 0 {
+		// Both parents are the same tour.
+		return clone(p1)
+	}
+
+	// Walk an alternating cycle from a random vertex. Each vertex has as many
+	// remaining edges of each parent, so the walk can always be continued until
+	// it closes on the start vertex with an edge of the second parent.
+	start := starts[rng.Intn(len(starts))]
+	cur, fromA := start, true
+	var cycle [][2]int
+	for {
+		g := rb
+		if fromA {
+			g = ra
+		}
+		next := g[cur][rng.Intn(len(g[cur]))]
+		g.unlink(cur, next)
+		cycle = append(cycle, [2]int{cur, next})
+		cur, fromA = next, !fromA
+		if cur == start && fromA {
+			break
+		}
+	}
+
+	// Replace the edges of the cycle coming from p1 by those coming from p2.
+	child := ga
+	for i, e := range cycle {
+		if i%2 == 0 {
+			child.unlink(e[0], e[1])
+		}
+	}
+	for i, e := range cycle {
+		if i%2 == 1 {
+			child.link(e[0], e[1])
+		}
+	}
+
+	x.mergeSubtours(child, rng, func(a, b int) float64 {
+		if x.Distance == nil {
+			return 0
+		}
+		return x.Distance(p1[a], p1[b])
+	})
+
+	off := make([]T, 0, n)
+	prev, cur := -1, 0
+	for len(off) < n {
+		off = append(off, p1[cur])
+		next := child[cur][0]
+		if next == prev {
+			next = child[cur][1]
+		}
+		prev, cur = cur, next
+	}
+	return off
+}
+
+// mergeSubtours merges the subtours of g, a graph of degree 2, into a single
+// tour. The smallest subtour is repeatedly merged with another one, by
+// replacing an edge of each with the 2 edges of minimum total distance.
+func (x EAX[T]) mergeSubtours(g tourGraph, rng *rand.Rand, dist func(a, b int) float64) {
+	for {
+		// Label vertices with their subtour.
+		label := make([]int, len(g))
+		for i := range label {
+			label[i] = -1
+		}
+		var sizes []int
+		for v := range g {
+			if label[v] != -1 {
+				continue
+			}
+			sub := len(sizes)
+			sizes = append(sizes, 0)
+			prev, cur := -1, v
+			for label[cur] == -1 {
+				label[cur] = sub
+				sizes[sub]++
+				next := g[cur][0]
+				if next == prev {
+					next = g[cur][1]
+				}
+				prev, cur = cur, next
+			}
+		}
+		if len(sizes) == 1 {
+			return
+		}
+
+		smallest := 0
+		for i, s := range sizes {
+			if s < sizes[smallest] {
+				smallest = i
+			}
+		}
+
+		// Find the best pair of edges (u1, u2) in the smallest subtour and
+		// (v1, v2) outside of it, to be replaced by (u1, v1) and (u2, v2). Ties
+		// are broken randomly.
+		best, nbest := math.Inf(1), 0
+		var bu1, bu2, bv1, bv2 int
+		for u1 := range g {
+			if label[u1] != smallest {
+				continue
+			}
+			for _, u2 := range g[u1] {
+				for v1 := range g {
+					if label[v1] == smallest {
+						continue
+					}
+					for _, v2 := range g[v1] {
+						d := dist(u1, v1) + dist(u2, v2) - dist(u1, u2) - dist(v1, v2)
+						switch {
+						case d < best:
+							best, nbest = d, 1
+						case d == best:
+							nbest++
+							if rng.Intn(nbest) != 0 {
+								continue
+							}
+						default:
+							continue
+						}
+						bu1, bu2, bv1, bv2 = u1, u2, v1, v2
+					}
+				}
+			}
+		}
+		g.unlink(bu1, bu2)
+		g.unlink(bv1, bv2)
+		g.link(bu1, bv1)
+		g.link(bu2, bv2)
+	}
+}
+
+func removeOne(s []int, v int) []int {
+	for i := range s {
+		if s[i] == v {
+			return append(s[:i], s[i+1:]...)
+		}
+	}
+	return s
+}
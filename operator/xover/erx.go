@@ -0,0 +1,96 @@
+package xover
+
+import "math/rand"
+
+// ERX implements the edge recombination crossover, on permutations.
+//
+// ERX considers permutations as cycles, that is TSP tours, and builds
+// offspring that preserve as much as possible the adjacencies (edges) of their
+// parents. Starting from the first element of a parent, it repeatedly moves to
+// the neighbour, in either parent, having the fewest remaining neighbours.
+//
+// ERX ignores the number of crossover points.
+type ERX[T comparable] struct{}
+
+// Mate mates 2 parents and generates a pair of offsprings with ERX.
+func (ERX[T]) Mate(p1, p2 []T, _ int, rng *rand.Rand) (off1, off2 []T) {
+	checkPermutations("ERX", p1, p2)
+	if len(p1) < 2 {
+		return clone(p1), clone(p2)
+	}
+
+	return erx(p1, p2, p1[0], rng), erx(p1, p2, p2[0], rng)
+}
+
+// erx builds an offspring of p1 and p2 starting with cur.
+func erx[T comparable](p1, p2 []T, cur T, rng *rand.Rand) []T {
+	n := len(p1)
+
+	// Build the union of the neighbours of each element in both parents.
+	neighbours := make(map[T][]T, n)
+	link := func(a, b T) {
+		if a == b || contains(neighbours[a], b) {
+			return
+		}
+		neighbours[a] = append(neighbours[a], b)
+		neighbours[b] = append(neighbours[b], a)
+	}
+	for _, p := range [2][]T{p1, p2} {
+		for i := range p {
+			link(p[i], p[(i+1)%n])
+		}
+	}
+
+	off := make([]T, 0, n)
+	used := make(map[T]bool, n)
+	for {
+		off = append(off, cur)
+		used[cur] = true
+		if len(off) == n {
+			return off
+		}
+
+		// Remove cur from the neighbour lists.
+		for _, nb := range neighbours[cur] {
+			neighbours[nb] = remove(neighbours[nb], cur)
+		}
+
+		// Pick the neighbour having the fewest neighbours, breaking ties
+		// randomly. If there's none, pick a random unused element.
+		var cands []T
+		for _, nb := range neighbours[cur] {
+			switch {
+			case len(cands) == 0 || len(neighbours[nb]) < len(neighbours[cands[0]]):
+				cands = append(cands[:0], nb)
+			case len(neighbours[nb]) == len(neighbours[cands[0]]):
+				cands = append(cands, nb)
+			}
+		}
+		if len(cands) == 0 {
+			for _, v := range p1 {
+				if !used[v] {
+					cands = append(cands, v)
+				}
+			}
+		}
+		cur = cands[rng.Intn(len(cands))]
+	}
+}
+
+func contains[T comparable](s []T, v T) bool {
+	for i := range s {
+		if s[i] == v {
+			return true
+		}
+	}
+	return false
+}
+
+func remove[T comparable](s []T, v T) []T {
+	for i := range s {
+		if s[i] == v {
+			return append(s[:i], s[i+1:]...)
+		}
+	}
+	return s
+}
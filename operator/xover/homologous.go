@@ -0,0 +1,38 @@
+package xover
+
+import "math/rand"
+
+// Homologous mates pairs of slices of possibly different lengths using
+// homologous crossover: cut points are chosen at the same positions in both
+// parents, within their common length, so that exchanged segments hold genes
+// at the same loci. Each offspring has the length of one of its parents.
+type Homologous[T any] struct{}
+
+// Mate performs homologous crossover on a pair of parents to generate a pair of
+// offspring, with nxpts crossover points.
+func (m Homologous[T]) Mate(p1, p2 []T, nxpts int, rng *rand.Rand) (off1, off2 []T) {
+	off1, off2 = clone(p1), clone(p2)
+
+	// Cut points are in the common length of both parents, and always greater
+	// than zero, so that we always pick a point that will result in a
+	// meaningful crossover. For parents of the same length, cutting at the end
+	// would just swap them.
+	n := len(p1)
+	if len(p2) < n {
+		n = len(p2)
+	}
+	if len(p1) == len(p2) {
+		n--
+	}
+	if n < 1 {
+		return off1, off2
+	}
+
+	for i := 0; i < nxpts; i++ {
+		xidx := 1 + rng.Intn(n)
+		for j := 0; j < xidx; j++ {
+			off1[j], off2[j] = off2[j], off1[j]
+		}
+	}
+	return off1, off2
+}
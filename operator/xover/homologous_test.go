@@ -0,0 +1,52 @@
+package xover
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHomologous(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	xover := New[[]int](Homologous[int]{})
+	xover.Probability = generator.Const(1.0)
+	xover.Points = generator.Const(2)
+
+	p1 := []int{1, 2, 3, 4, 5, 6}
+	p2 := []int{-1, -2, -3}
+	for i := 0; i < 100; i++ {
+		off := xover.Apply([][]int{p1, p2}, rng)
+		require.Len(t, off, 2)
+
+		// Genes keep their locus.
+		lengths := []int{len(off[0]), len(off[1])}
+		assert.ElementsMatch(t, []int{3, 6}, lengths)
+		for _, o := range off {
+			for j, g := range o {
+				require.Truef(t, g == j+1 || g == -(j+1), "gene %d at wrong locus %d", g, j)
+			}
+		}
+	}
+}
+
+func TestHomologousSameLength(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	p1 := []int{1, 2, 3, 4}
+	p2 := []int{-1, -2, -3, -4}
+	for i := 0; i < 100; i++ {
+		off1, off2 := Homologous[int]{}.Mate(p1, p2, 1, rng)
+		assert.NotEqual(t, p2, off1, "parents should not be just swapped")
+		assert.NotEqual(t, p1, off2, "parents should not be just swapped")
+		assert.Equal(t, -off1[3], off2[3])
+	}
+
+	off1, off2 := Homologous[int]{}.Mate([]int{1}, []int{2}, 1, rng)
+	assert.Equal(t, []int{1}, off1)
+	assert.Equal(t, []int{2}, off2)
+}
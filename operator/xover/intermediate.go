@@ -0,0 +1,68 @@
+package xover
+
+import (
+	"math/rand"
+)
+
+// Intermediate is an operator performing intermediate recombination of
+// multiple parents on real-valued vectors, as used in evolution strategies:
+// each offspring is the mean of Parents candidates, the candidate it replaces
+// and Parents-1 other ones, randomly chosen among the selected candidates.
+//
+// Since offspring are convex combinations of their parents, offspring of
+// parents within bounds are within bounds too.
+type Intermediate struct {
+	// Parents is the number of parents of each offspring. If 0, it defaults to
+	// 2. It's capped at the number of selected candidates.
+	Parents int
+}
+
+// Apply applies intermediate recombination to the selected candidates. Each
+// offspring takes the place of its first parent.
+func (op *Intermediate) Apply(sel [][]float64, rng *rand.Rand) [][]float64 {
+	if len(sel) == 0 {
+		return nil
+	}
+	rho := op.Parents
+	if rho == 0 {
+		rho = 2
+	}
+	if rho > len(sel) {
+		rho = len(sel)
+	}
+
+	// Indices of the other candidates, shuffled in place. Whatever their order,
+	// the rho-1 first elements of a partial Fisher-Yates shuffle are a uniform
+	// random sample, so the buffer is not reset between offspring.
+	others := make([]int, len(sel)-1)
+	for i := range others {
+		others[i] = i
+	}
+
+	res := make([][]float64, len(sel))
+	for i, p := range sel {
+		off := make([]float64, len(p))
+		copy(off, p)
+
+		// Pick rho-1 other distinct parents.
+		for k := 0; k < rho-1; k++ {
+			r := k + rng.Intn(len(others)-k)
+			others[k], others[r] = others[r], others[k]
+			j := others[k]
+			if j >= i {
+				j++
+			}
+			if len(sel[j]) != len(off) {
+				panic("Intermediate only recombines slices of the same length")
+			}
+			for k := range off {
+				off[k] += sel[j][k]
+			}
+		}
+		for k := range off {
+			off[k] /= float64(rho)
+		}
+		res[i] = off
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package xover
+
+import (
+	"math"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntermediate(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	sel := [][]float64{{0, 0}, {4, 8}, {8, 4}}
+
+	// With as many parents as candidates, all offspring are the centroid.
+	off := (&Intermediate{Parents: 3}).Apply(sel, rng)
+	require.Len(t, off, 3)
+	for _, x := range off {
+		assert.InDeltaSlice(t, []float64{4, 4}, x, 1e-9)
+	}
+
+	// With 2 parents, each offspring is the midpoint of the candidate it
+	// replaces and another one.
+	off = (&Intermediate{}).Apply(sel, rng)
+	require.Len(t, off, 3)
+	mid := [][]float64{{2, 4}, {4, 2}, {6, 6}}
+	for i, x := range off {
+		assert.Contains(t, mid, x)
+		assert.NotEqual(t, sel[i], x)
+	}
+	assert.Equal(t, []float64{0, 0}, sel[0], "selected candidate has been modified")
+
+	// Parents is capped at the number of candidates.
+	off = (&Intermediate{Parents: 10}).Apply(sel[:1], rng)
+	assert.Equal(t, [][]float64{{0, 0}}, off)
+	assert.Empty(t, (&Intermediate{}).Apply(nil, rng))
+}
+
+func TestIntermediateDistinctParents(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	// Candidate i has a single gene, 2^i, so that the parents of an offspring
+	// can be read from the bits of their sum.
+	sel := make([][]float64, 10)
+	for i := range sel {
+		sel[i] = []float64{float64(int(1) << i)}
+	}
+
+	op := &Intermediate{Parents: 4}
+	picked := make([]int, len(sel))
+	for n := 0; n < 1000; n++ {
+		for i, x := range op.Apply(sel, rng) {
+			sum := int(math.Round(x[0] * 4))
+			require.Equalf(t, 4, bits.OnesCount(uint(sum)), "offspring %d has duplicate parents: %b", i, sum)
+			require.NotZerof(t, sum&(1<<i), "offspring %d is not bred from the candidate it replaces: %b", i, sum)
+			for j := range sel {
+				if j != i && sum&(1<<j) != 0 {
+					picked[j]++
+				}
+			}
+		}
+	}
+
+	// Each candidate is picked as one of the 3 other parents of the 9 other
+	// offspring with probability 3/9.
+	for j, n := range picked {
+		assert.InDeltaf(t, 1000*9*3/9, n, 150, "candidate %d picked %d times", j, n)
+	}
+}
@@ -0,0 +1,123 @@
+package xover
+
+import "math/rand"
+
+// OX1 implements the order crossover (OX1), on permutations.
+//
+// A random segment is copied from the first parent into the first offspring,
+// which is then completed with the remaining elements, in the order they
+// appear in the second parent, starting after the segment. The second
+// offspring is produced the same way, with the parents roles swapped. OX1
+// preserves the relative order of the elements, and is well suited to cyclic
+// permutations such as TSP tours.
+//
+// OX1 ignores the number of crossover points.
+type OX1[T comparable] struct{}
+
+// Mate mates 2 parents and generates a pair of offsprings with OX1.
+func (OX1[T]) Mate(p1, p2 []T, _ int, rng *rand.Rand) (off1, off2 []T) {
+	checkPermutations("OX1", p1, p2)
+	if len(p1) < 2 {
+		return clone(p1), clone(p2)
+	}
+
+	a, b := cutPoints(len(p1), rng)
+	return ox1(p1, p2, a, b), ox1(p2, p1, a, b)
+}
+
+// ox1 returns the offspring made of the [a, b) segment of p1, completed with
+// the elements of p2.
+func ox1[T comparable](p1, p2 []T, a, b int) []T {
+	n := len(p1)
+	off := make([]T, n)
+	used := make(map[T]bool, b-a)
+	for i := a; i < b; i++ {
+		off[i] = p1[i]
+		used[p1[i]] = true
+	}
+
+	pos := b % n
+	for i := 0; i < n; i++ {
+		v := p2[(b+i)%n]
+		if used[v] {
+			continue
+		}
+		off[pos] = v
+		pos = (pos + 1) % n
+	}
+	return off
+}
+
+// OX2 implements the order-based crossover (OX2), on permutations.
+//
+// A random set of positions is chosen. The elements found at those positions in
+// the second parent are located in the first parent, and reordered in the
+// first offspring in the order they appear in the second parent. The second
+// offspring is produced the same way, with the parents roles swapped. OX2
+// preserves relative orders, and is well suited to scheduling problems.
+//
+// OX2 ignores the number of crossover points.
+type OX2[T comparable] struct{}
+
+// Mate mates 2 parents and generates a pair of offsprings with OX2.
+func (OX2[T]) Mate(p1, p2 []T, _ int, rng *rand.Rand) (off1, off2 []T) {
+	checkPermutations("OX2", p1, p2)
+
+	pos := randomPositions(len(p1), rng)
+	return ox2(p1, p2, pos), ox2(p2, p1, pos)
+}
+
+// ox2 returns a copy of p1 in which the elements found in p2 at the given
+// positions are reordered as in p2.
+func ox2[T comparable](p1, p2 []T, pos []int) []T {
+	selected := make(map[T]bool, len(pos))
+	for _, i := range pos {
+		selected[p2[i]] = true
+	}
+
+	off := clone(p1)
+	j := 0
+	for i, v := range off {
+		if selected[v] {
+			off[i] = p2[pos[j]]
+			j++
+		}
+	}
+	return off
+}
+
+// checkPermutations panics if p1 and p2 don't have the same length.
+func checkPermutations[T any](name string, p1, p2 []T) {
+	if len(p1) != len(p2) {
+		panic(name + " cannot mate parents of different lengths")
+	}
+}
+
+// cutPoints returns 2 random cut points a < b, in [0, n].
+func cutPoints(n int, rng *rand.Rand) (a, b int) {
+	a, b = rng.Intn(n+1), rng.Intn(n)
+	if b >= a {
+		b++
+	} else {
+		a, b = b, a
+	}
+	return a, b
+}
+
+// randomPositions returns a random subset of the [0, n) positions, in
+// increasing order, each position being chosen with probability 0.5.
+func randomPositions(n int, rng *rand.Rand) []int {
+	var pos []int
+	for i := 0; i < n; i++ {
+		if rng.Intn(2) == 0 {
+			pos = append(pos, i)
+		}
+	}
+	return pos
+}
+
+func clone[T any](s []T) []T {
+	c := make([]T, len(s))
+	copy(c, s)
+	return c
+}
@@ -0,0 +1,77 @@
+package xover
+
+import "math/rand"
+
+// PBX implements the position-based crossover, on permutations.
+//
+// A random set of positions is chosen. The first offspring inherits the
+// elements of the first parent at those positions, the other positions are
+// filled with the remaining elements, in the order they appear in the second
+// parent. The second offspring is produced the same way, with the parents
+// roles swapped.
+//
+// PBX ignores the number of crossover points.
+type PBX[T comparable] struct{}
+
+// Mate mates 2 parents and generates a pair of offsprings with PBX.
+func (PBX[T]) Mate(p1, p2 []T, _ int, rng *rand.Rand) (off1, off2 []T) {
+	checkPermutations("PBX", p1, p2)
+
+	pos := randomPositions(len(p1), rng)
+	return pbx(p1, p2, pos), pbx(p2, p1, pos)
+}
+
+// pbx returns the offspring inheriting the elements of p1 at the given
+// positions, completed with the elements of p2.
+func pbx[T comparable](p1, p2 []T, pos []int) []T {
+	off := make([]T, len(p1))
+	fixed := make([]bool, len(p1))
+	used := make(map[T]bool, len(pos))
+	for _, i := range pos {
+		off[i] = p1[i]
+		fixed[i] = true
+		used[p1[i]] = true
+	}
+
+	j := 0
+	for _, v := range p2 {
+		if used[v] {
+			continue
+		}
+		for fixed[j] {
+			j++
+		}
+		off[j] = v
+		j++
+	}
+	return off
+}
+
+// AP implements the alternating position crossover, on permutations.
+//
+// The first offspring is built by taking alternatively the next element of
+// the first and second parents, skipping the elements already present. The
+// second offspring is built the same way, starting with the second parent.
+//
+// AP ignores the number of crossover points, and is deterministic.
+type AP[T comparable] struct{}
+
+// Mate mates 2 parents and generates a pair of offsprings with AP.
+func (AP[T]) Mate(p1, p2 []T, _ int, _ *rand.Rand) (off1, off2 []T) {
+	checkPermutations("AP", p1, p2)
+	return ap(p1, p2), ap(p2, p1)
+}
+
+func ap[T comparable](p1, p2 []T) []T {
+	off := make([]T, 0, len(p1))
+	used := make(map[T]bool, len(p1))
+	for i := range p1 {
+		for _, v := range [2]T{p1[i], p2[i]} {
+			if !used[v] {
+				used[v] = true
+				off = append(off, v)
+			}
+		}
+	}
+	return off
+}
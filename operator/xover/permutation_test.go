@@ -0,0 +1,245 @@
+package xover
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/arl/evolve/factory"
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// permutationMaters holds all the crossovers operating on permutations.
+func permutationMaters() map[string]Mater[[]int] {
+	return map[string]Mater[[]int]{
+		"OX1": OX1[int]{},
+		"OX2": OX2[int]{},
+		"CX":  CX[int]{},
+		"ERX": ERX[int]{},
+		"EAX": EAX[int]{},
+		"EAX with distance": EAX[int]{Distance: func(a, b int) float64 {
+			return math.Abs(float64(a - b))
+		}},
+		"PBX": PBX[int]{},
+		"AP":  AP[int]{},
+	}
+}
+
+// isPermutation reports whether s is a permutation of want.
+func isPermutation(s, want []int) bool {
+	if len(s) != len(want) {
+		return false
+	}
+	a, b := append([]int(nil), s...), append([]int(nil), want...)
+	sort.Ints(a)
+	sort.Ints(b)
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPermutationCrossoversValidity(t *testing.T) {
+	for name, m := range permutationMaters() {
+		t.Run(name, func(t *testing.T) {
+			rng := rand.New(rand.NewSource(99))
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 8, 13, 50} {
+				items := make([]int, n)
+				for i := range items {
+					items[i] = i * 3 // not a 0..n-1 sequence
+				}
+				f := factory.Permutation[int](items)
+
+				for i := 0; i < 200; i++ {
+					p1, p2 := f.New(rng), f.New(rng)
+					c1, c2 := clone(p1), clone(p2)
+
+					off1, off2 := m.Mate(p1, p2, 2, rng)
+					require.Truef(t, isPermutation(off1, items), "n=%d: invalid offspring %v of %v and %v", n, off1, p1, p2)
+					require.Truef(t, isPermutation(off2, items), "n=%d: invalid offspring %v of %v and %v", n, off2, p1, p2)
+					require.Equal(t, c1, p1, "parent has been modified")
+					require.Equal(t, c2, p2, "parent has been modified")
+				}
+			}
+		})
+	}
+}
+
+func TestPermutationCrossoversSameParents(t *testing.T) {
+	// Mating a permutation with itself gives the same permutation, except for
+	// ERX and EAX, which consider permutations as cycles.
+	p := []int{4, 2, 7, 0, 1, 6, 5, 3}
+	for name, m := range permutationMaters() {
+		rng := rand.New(rand.NewSource(99))
+		off1, off2 := m.Mate(p, p, 2, rng)
+		if name[:2] == "ER" || name[:2] == "EA" {
+			assert.Equalf(t, p[0], off1[0], "%s", name)
+			assert.Truef(t, sameCycle(p, off1), "%s: %v is not the same cycle as %v", name, off1, p)
+			assert.Truef(t, sameCycle(p, off2), "%s: %v is not the same cycle as %v", name, off2, p)
+			continue
+		}
+		assert.Equalf(t, p, off1, "%s", name)
+		assert.Equalf(t, p, off2, "%s", name)
+	}
+}
+
+// sameCycle reports whether a and b have the same edges, when considered as
+// cycles.
+func sameCycle(a, b []int) bool {
+	edges := make(map[[2]int]bool)
+	edge := func(u, v int) [2]int {
+		if u > v {
+			u, v = v, u
+		}
+		return [2]int{u, v}
+	}
+	for i := range a {
+		edges[edge(a[i], a[(i+1)%len(a)])] = true
+	}
+	for i := range b {
+		if !edges[edge(b[i], b[(i+1)%len(b)])] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestOX1(t *testing.T) {
+	p1 := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	p2 := []int{9, 3, 7, 8, 2, 6, 5, 1, 4}
+
+	assert.Equal(t, []int{3, 8, 2, 4, 5, 6, 7, 1, 9}, ox1(p1, p2, 3, 7))
+	assert.Equal(t, []int{3, 4, 7, 8, 2, 6, 5, 9, 1}, ox1(p2, p1, 3, 7))
+}
+
+func TestOX2(t *testing.T) {
+	p1 := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	p2 := []int{2, 4, 6, 8, 7, 5, 3, 1}
+
+	// Elements of p2 at positions 1, 2 and 5 are 4, 6 and 5.
+	assert.Equal(t, []int{1, 2, 3, 4, 6, 5, 7, 8}, ox2(p1, p2, []int{1, 2, 5}))
+}
+
+func TestCX(t *testing.T) {
+	p1 := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	p2 := []int{8, 5, 2, 1, 3, 6, 4, 7}
+
+	off1, off2 := CX[int]{}.Mate(p1, p2, 2, nil)
+	assert.Equal(t, []int{1, 5, 2, 4, 3, 6, 7, 8}, off1)
+	assert.Equal(t, []int{8, 2, 3, 1, 5, 6, 4, 7}, off2)
+
+	// Each element keeps the position it has in one of the parents.
+	for i := range off1 {
+		assert.True(t, off1[i] == p1[i] || off1[i] == p2[i])
+	}
+}
+
+func TestPBX(t *testing.T) {
+	p1 := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	p2 := []int{2, 4, 6, 8, 7, 5, 3, 1}
+
+	assert.Equal(t, []int{4, 2, 3, 8, 7, 6, 5, 1}, pbx(p1, p2, []int{1, 2, 5}))
+}
+
+func TestAP(t *testing.T) {
+	p1 := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	p2 := []int{3, 7, 5, 1, 6, 8, 2, 4}
+
+	off1, off2 := AP[int]{}.Mate(p1, p2, 2, nil)
+	assert.Equal(t, []int{1, 3, 2, 7, 5, 4, 6, 8}, off1)
+	assert.Equal(t, []int{3, 1, 7, 2, 5, 4, 6, 8}, off2)
+}
+
+func TestERXPreservesEdges(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	f := factory.Permutation[int]([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+
+	// Most edges of ERX offspring come from their parents.
+	var inherited, total int
+	for i := 0; i < 100; i++ {
+		p1, p2 := f.New(rng), f.New(rng)
+		off, _ := ERX[int]{}.Mate(p1, p2, 2, rng)
+		for j := range off {
+			a, b := off[j], off[(j+1)%len(off)]
+			if hasEdge(p1, a, b) || hasEdge(p2, a, b) {
+				inherited++
+			}
+			total++
+		}
+	}
+	assert.Greater(t, float64(inherited)/float64(total), 0.85)
+}
+
+func hasEdge(tour []int, a, b int) bool {
+	for i := range tour {
+		u, v := tour[i], tour[(i+1)%len(tour)]
+		if (u == a && v == b) || (u == b && v == a) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEAXShortensTours(t *testing.T) {
+	// Cities on a circle: the optimal tour visits them in order.
+	const n = 30
+	dist := func(a, b int) float64 {
+		d := math.Abs(float64(a - b))
+		return math.Min(d, n-d)
+	}
+	length := func(tour []int) float64 {
+		var l float64
+		for i := range tour {
+			l += dist(tour[i], tour[(i+1)%len(tour)])
+		}
+		return l
+	}
+
+	items := make([]int, n)
+	for i := range items {
+		items[i] = i
+	}
+
+	rng := rand.New(rand.NewSource(99))
+	xover := New[[]int](EAX[int]{Distance: dist})
+	xover.Probability = generator.Const(1.0)
+	xover.Points = generator.Const(1)
+
+	pop := make([][]int, 20)
+	for i := range pop {
+		pop[i] = factory.Permutation[int](items).New(rng)
+	}
+	var before float64
+	for _, tour := range pop {
+		before += length(tour)
+	}
+
+	// Keep the best of parents and offspring.
+	for gen := 0; gen < 20; gen++ {
+		off := xover.Apply(pop, rng)
+		for i := range pop {
+			if length(off[i]) < length(pop[i]) {
+				pop[i] = off[i]
+			}
+		}
+	}
+	var after float64
+	for _, tour := range pop {
+		after += length(tour)
+	}
+	assert.Less(t, after, before/2)
+}
+
+func TestPermutationCrossoversDifferentLength(t *testing.T) {
+	for name, m := range permutationMaters() {
+		assert.Panicsf(t, func() {
+			m.Mate([]int{1, 2, 3}, []int{1, 2}, 2, rand.New(rand.NewSource(99)))
+		}, "%s", name)
+	}
+}
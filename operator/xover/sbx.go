@@ -0,0 +1,83 @@
+package xover
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/arl/evolve"
+)
+
+// SBX mates pairs of real-valued vectors using simulated binary crossover, which
+// simulates on real values the behaviour of single-point crossover on binary
+// strings: offspring genes are spread around parents genes, with a spread
+// controlled by the distribution index.
+//
+// SBX ignores the number of crossover points.
+type SBX struct {
+	// Eta is the distribution index. Large values produce offspring close to
+	// their parents, while small values allow distant offspring. If 0, it
+	// defaults to 15.
+	Eta float64
+
+	// Probability is the probability for each pair of genes to be crossed
+	// over. If 0, it defaults to 0.5.
+	Probability float64
+
+	// Bounds, if set, holds the range of each gene. Offspring genes are then
+	// generated with the bounded variant of SBX and are always within bounds.
+	Bounds *evolve.Bounds
+}
+
+// Mate performs simulated binary crossover on a pair of parents to generate a
+// pair of offspring.
+func (m SBX) Mate(p1, p2 []float64, _ int, rng *rand.Rand) (off1, off2 []float64) {
+	if len(p1) != len(p2) {
+		panic("SBX only mates slices of the same length")
+	}
+	eta, prob := m.Eta, m.Probability
+	if eta == 0 {
+		eta = 15
+	}
+	if prob == 0 {
+		prob = 0.5
+	}
+
+	off1 = make([]float64, len(p1))
+	off2 = make([]float64, len(p2))
+	copy(off1, p1)
+	copy(off2, p2)
+
+	for i := range p1 {
+		if rng.Float64() >= prob || math.Abs(p1[i]-p2[i]) < 1e-14 {
+			continue
+		}
+		lo, hi := math.Inf(-1), math.Inf(1)
+		if m.Bounds != nil {
+			lo, hi = m.Bounds.Min[i], m.Bounds.Max[i]
+		}
+
+		y1, y2 := math.Min(p1[i], p2[i]), math.Max(p1[i], p2[i])
+		u := rng.Float64()
+		c1 := 0.5 * (y1 + y2 - sbxSpread(1+2*(y1-lo)/(y2-y1), eta, u)*(y2-y1))
+		c2 := 0.5 * (y1 + y2 + sbxSpread(1+2*(hi-y2)/(y2-y1), eta, u)*(y2-y1))
+		c1 = math.Max(lo, math.Min(hi, c1))
+		c2 = math.Max(lo, math.Min(hi, c2))
+
+		if rng.Float64() < 0.5 {
+			c1, c2 = c2, c1
+		}
+		off1[i], off2[i] = c1, c2
+	}
+	return off1, off2
+}
+
+// sbxSpread returns the spread factor of SBX, given beta, the ratio between the
+// distance from the parents to the nearest bound and the distance between
+// the parents, the distribution index eta and a uniformly distributed u.
+func sbxSpread(beta, eta, u float64) float64 {
+	alpha := 2 - math.Pow(beta, -(eta+1))
+	if u <= 1/alpha {
+		return math.Pow(u*alpha, 1/(eta+1))
+	}
+	return math.Pow(1/(2-u*alpha), 1/(eta+1))
+}
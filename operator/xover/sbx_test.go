@@ -0,0 +1,62 @@
+package xover
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve"
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSBX(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	bounds := evolve.UniformBounds(4, -1, 1)
+
+	xover := New[[]float64](SBX{Eta: 2, Probability: 1, Bounds: &bounds})
+	xover.Probability = generator.Const(1.0)
+	xover.Points = generator.Const(1)
+
+	pop := [][]float64{{-1, -0.5, 0.5, 0.9}, {1, 0.5, -0.5, 1}}
+	for i := 0; i < 100; i++ {
+		off := xover.Apply(pop, rng)
+		require.Len(t, off, 2)
+		for _, x := range off {
+			require.Truef(t, bounds.Contains(x), "offspring out of bounds: %v", x)
+		}
+	}
+}
+
+func TestSBXPreservesMean(t *testing.T) {
+	// Without bounds, the mean of offspring genes equals the mean of parents
+	// genes.
+	rng := rand.New(rand.NewSource(99))
+	m := SBX{Probability: 1}
+
+	p1, p2 := []float64{1, 2, 3}, []float64{-4, 5, 3}
+	for i := 0; i < 100; i++ {
+		off1, off2 := m.Mate(p1, p2, 1, rng)
+		for k := range p1 {
+			assert.InDelta(t, p1[k]+p2[k], off1[k]+off2[k], 1e-9)
+		}
+	}
+}
+
+func TestSBXDistributionIndex(t *testing.T) {
+	// Large distribution indices produce offspring closer to their parents.
+	spread := func(eta float64) float64 {
+		rng := rand.New(rand.NewSource(99))
+		m := SBX{Eta: eta, Probability: 1}
+		var sum float64
+		for i := 0; i < 1000; i++ {
+			off1, _ := m.Mate([]float64{0}, []float64{1}, 1, rng)
+			sum += math.Min(math.Abs(off1[0]), math.Abs(off1[0]-1))
+		}
+		return sum
+	}
+
+	assert.Less(t, spread(20), spread(2))
+}
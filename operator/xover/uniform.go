@@ -0,0 +1,42 @@
+package xover
+
+import (
+	"math/rand"
+
+	"github.com/arl/evolve/generator"
+)
+
+// Uniform mates pairs of slices using uniform crossover: each pair of genes is
+// swapped between the offspring with a given probability. Since genes are only
+// exchanged, offspring of parents within bounds are within bounds too.
+//
+// Uniform ignores the number of crossover points.
+type Uniform[T any] struct {
+	// Probability generates the swap probability of each crossover. If nil,
+	// genes are swapped with probability 0.5.
+	Probability generator.Float
+}
+
+// Mate performs uniform crossover on a pair of parents to generate a pair of
+// offspring.
+func (m Uniform[T]) Mate(p1, p2 []T, _ int, rng *rand.Rand) (off1, off2 []T) {
+	if len(p1) != len(p2) {
+		panic("Uniform only mates slices of the same length")
+	}
+
+	prob := 0.5
+	if m.Probability != nil {
+		prob = m.Probability.Next()
+	}
+
+	off1 = make([]T, len(p1))
+	off2 = make([]T, len(p2))
+	copy(off1, p1)
+	copy(off2, p2)
+	for i := range p1 {
+		if rng.Float64() < prob {
+			off1[i], off2[i] = off2[i], off1[i]
+		}
+	}
+	return off1, off2
+}
@@ -0,0 +1,41 @@
+package xover
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/arl/evolve/generator"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniform(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+	m := Uniform[int]{}
+
+	p1 := []int{0, 0, 0, 0, 0, 0, 0, 0}
+	p2 := []int{1, 1, 1, 1, 1, 1, 1, 1}
+	var swapped int
+	for i := 0; i < 100; i++ {
+		off1, off2 := m.Mate(p1, p2, 1, rng)
+		for k := range p1 {
+			// Genes are exchanged, not modified.
+			assert.Equal(t, 1, off1[k]+off2[k])
+			swapped += off1[k]
+		}
+	}
+	assert.InEpsilon(t, 400, swapped, 0.1)
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 0, 0, 0}, p1, "parent has been modified")
+}
+
+func TestUniformProbability(t *testing.T) {
+	rng := rand.New(rand.NewSource(99))
+
+	off1, off2 := Uniform[float64]{Probability: generator.Const(1.0)}.Mate([]float64{1, 2}, []float64{3, 4}, 1, rng)
+	assert.Equal(t, []float64{3, 4}, off1)
+	assert.Equal(t, []float64{1, 2}, off2)
+
+	off1, off2 = Uniform[float64]{Probability: generator.Const(0.0)}.Mate([]float64{1, 2}, []float64{3, 4}, 1, rng)
+	assert.Equal(t, []float64{1, 2}, off1)
+	assert.Equal(t, []float64{3, 4}, off2)
+}
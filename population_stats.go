@@ -32,4 +32,73 @@ type PopulationStats[T any] struct {
 
 	// Elapsed is the duration elapsed since the evolution start.
 	Elapsed time.Duration
+
+	// Evaluations is the total number of fitness evaluations performed since
+	// the evolution start.
+	Evaluations int
+
+	// HallOfFame holds the members of the hall of fame, that is the best
+	// individuals seen since the evolution start, sorted from the fittest to
+	// the least fit. It's nil if the engine doesn't keep a hall of fame.
+	HallOfFame *Population[T]
+
+	// Restarts is the number of times the evolution has been restarted.
+	Restarts int
+
+	// Species is the number of species the population is divided into, or 0
+	// if the evolutionary algorithm doesn't perform speciation. Note that
+	// engine.Speciation reports the species of the previous generation, see
+	// Speciation.AnnotateStats.
+	Species int
+
+	// Coverage is the ratio of cells of a quality-diversity archive that are
+	// filled, or 0 if the evolutionary algorithm doesn't maintain an archive.
+	Coverage float64
+
+	// QDScore is the quality-diversity score, that is the sum of the fitness
+	// of all the candidates held in a quality-diversity archive, or 0 if the
+	// evolutionary algorithm doesn't maintain an archive.
+	QDScore float64
+
+	// Operators holds contribution statistics of the evolutionary operators,
+	// or nil if the operators aren't instrumented (see
+	// operator.Instrumented).
+	Operators []OperatorStats
+}
+
+// OperatorStats holds statistics about the contribution of an evolutionary
+// operator to the evolution, cumulated since the evolution start.
+type OperatorStats struct {
+	// Name is the operator name.
+	Name string
+
+	// Applied is the number of offspring produced by the operator.
+	Applied int
+
+	// Improved is the number of offspring that are fitter than the candidate
+	// they replace.
+	Improved int
+
+	// DeltaSum is the sum of the fitness improvements of the offspring over
+	// the candidates they replace. Improvements are positive, whether fitness
+	// is natural or not.
+	DeltaSum float64
+}
+
+// ImprovementRate returns the ratio of offspring that are fitter than the
+// candidate they replace, or 0 if the operator hasn't been applied.
+func (s OperatorStats) ImprovementRate() float64 {
+	if s.Applied == 0 {
+		return 0
+	}
+	return float64(s.Improved) / float64(s.Applied)
+}
+
+// MeanDelta returns the average fitness improvement of the offspring over the
+// candidate they replace, or 0 if the operator hasn't been applied.
+func (s OperatorStats) MeanDelta() float64 {
+	if s.Applied == 0 {
+		return 0
+	}
+	return s.DeltaSum / float64(s.Applied)
 }